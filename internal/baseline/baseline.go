@@ -0,0 +1,187 @@
+// Package baseline records known findings so legacy repositories can adopt
+// yardstick (including -strict) without fixing everything on day one.
+//
+// A baseline file lists fingerprints of accepted findings. On later runs,
+// findings whose fingerprint is in the baseline are reported as suppressed
+// and do not affect exit codes, while new findings still fail. Entries that
+// no longer occur are reported as stale so the baseline can shrink over time.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// Version is the current baseline file format version.
+const Version = 1
+
+// Entry is one accepted finding.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Check       string `json:"check"`
+	Path        string `json:"path"`
+	Message     string `json:"message"`
+}
+
+// File is the on-disk baseline document.
+type File struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+}
+
+// Fingerprint returns a stable identifier for a finding. It hashes the check
+// key, the slash-separated path relative to the scan root, and the message,
+// so the same finding matches across machines and checkout locations.
+func Fingerprint(check, relPath, message string) string {
+	sum := sha256.Sum256([]byte(check + "\x00" + relPath + "\x00" + message))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RelPath converts a finding path to a slash-separated path relative to root.
+// Paths outside root are returned unchanged.
+func RelPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// EntryFor builds the baseline entry for a finding.
+func EntryFor(root string, f checks.Finding) Entry {
+	rel := RelPath(root, f.Path)
+	return Entry{
+		Fingerprint: Fingerprint(f.Check, rel, f.Message),
+		Check:       f.Check,
+		Path:        rel,
+		Message:     f.Message,
+	}
+}
+
+// FromFindings records the actionable (warn and error) findings of a run.
+// Entries are deduplicated and sorted so baseline diffs stay reviewable.
+func FromFindings(root string, fs []checks.Finding) File {
+	out := File{Version: Version, Findings: []Entry{}}
+	seen := make(map[string]struct{})
+	for _, f := range fs {
+		if f.Level == checks.LevelInfo {
+			continue
+		}
+		e := EntryFor(root, f)
+		if _, ok := seen[e.Fingerprint]; ok {
+			continue
+		}
+		seen[e.Fingerprint] = struct{}{}
+		out.Findings = append(out.Findings, e)
+	}
+	sortEntries(out.Findings)
+	return out
+}
+
+// Merge combines a freshly recorded baseline with a previous one. Entries for
+// checks that did not run are carried over, so refreshing the baseline with
+// -only does not drop everything else.
+func Merge(prev *File, next File, ran map[string]struct{}) File {
+	if prev == nil {
+		return next
+	}
+	seen := make(map[string]struct{}, len(next.Findings))
+	for _, e := range next.Findings {
+		seen[e.Fingerprint] = struct{}{}
+	}
+	for _, e := range prev.Findings {
+		if _, ok := ran[e.Check]; ok {
+			continue
+		}
+		if _, ok := seen[e.Fingerprint]; ok {
+			continue
+		}
+		next.Findings = append(next.Findings, e)
+	}
+	sortEntries(next.Findings)
+	return next
+}
+
+// Load reads a baseline file.
+func Load(path string) (*File, error) {
+	// #nosec G304 -- baseline path is explicitly provided by the user.
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("baseline %s: %w", path, err)
+	}
+	if f.Version != Version {
+		return nil, fmt.Errorf("baseline %s: unsupported version %d, expected %d", path, f.Version, Version)
+	}
+	return &f, nil
+}
+
+// Write stores a baseline file at path. This is the only place yardstick
+// writes, and only to the path the user named with -baseline.
+func Write(path string, f File) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Apply marks findings matched by the baseline as suppressed and returns the
+// number suppressed plus the stale entries that no longer occur. Only entries
+// for checks in ran are considered stale, so running a subset with -only does
+// not flag the rest of the baseline.
+func (f *File) Apply(root string, fs []checks.Finding, ran map[string]struct{}) (int, []Entry) {
+	known := make(map[string]struct{}, len(f.Findings))
+	for _, e := range f.Findings {
+		known[e.Fingerprint] = struct{}{}
+	}
+
+	matched := make(map[string]struct{})
+	suppressed := 0
+	for i := range fs {
+		if fs[i].Level == checks.LevelInfo {
+			continue
+		}
+		fp := EntryFor(root, fs[i]).Fingerprint
+		if _, ok := known[fp]; ok {
+			fs[i].Suppressed = true
+			matched[fp] = struct{}{}
+			suppressed++
+		}
+	}
+
+	stale := []Entry{}
+	for _, e := range f.Findings {
+		if _, ok := ran[e.Check]; !ok {
+			continue
+		}
+		if _, ok := matched[e.Fingerprint]; !ok {
+			stale = append(stale, e)
+		}
+	}
+	sortEntries(stale)
+	return suppressed, stale
+}
+
+func sortEntries(es []Entry) {
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].Check != es[j].Check {
+			return es[i].Check < es[j].Check
+		}
+		if es[i].Path != es[j].Path {
+			return es[i].Path < es[j].Path
+		}
+		return es[i].Message < es[j].Message
+	})
+}
@@ -0,0 +1,79 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+func TestFingerprint_StableAcrossRoots(t *testing.T) {
+	a := EntryFor("/ci/work/repo", checks.Finding{Check: "readme", Path: "/ci/work/repo/README.md", Message: "Missing section: ## CI"})
+	b := EntryFor("/home/dev/repo", checks.Finding{Check: "readme", Path: "/home/dev/repo/README.md", Message: "Missing section: ## CI"})
+	if a.Fingerprint != b.Fingerprint {
+		t.Fatalf("fingerprint should not depend on the checkout location: %s vs %s", a.Fingerprint, b.Fingerprint)
+	}
+	if a.Path != "README.md" {
+		t.Fatalf("expected repo-relative path, got %q", a.Path)
+	}
+	c := EntryFor("/ci/work/repo", checks.Finding{Check: "readme", Path: "/ci/work/repo/README.md", Message: "Missing section: ## Usage"})
+	if c.Fingerprint == a.Fingerprint {
+		t.Fatalf("different messages must produce different fingerprints")
+	}
+	if got := RelPath("/repo", "/repo"); got != "." {
+		t.Fatalf("root path should be '.', got %q", got)
+	}
+}
+
+func TestApply_SuppressesKnownAndReportsStale(t *testing.T) {
+	root := "/repo"
+	recorded := FromFindings(root, []checks.Finding{
+		{Check: "license", Level: checks.LevelWarn, Path: "/repo/LICENSE", Message: "LICENSE missing"},
+		{Check: "changelog", Level: checks.LevelWarn, Path: "/repo/CHANGELOG.md", Message: "CHANGELOG.md missing"},
+		{Check: "manifest", Level: checks.LevelInfo, Path: "/repo/go.mod", Message: "Go project detected via go.mod"},
+		{Check: "codeowners", Level: checks.LevelWarn, Path: "/repo/CODEOWNERS", Message: "CODEOWNERS missing"},
+	})
+	if len(recorded.Findings) != 3 {
+		t.Fatalf("info findings should not be recorded, got %+v", recorded.Findings)
+	}
+
+	// license is still present, changelog was fixed, readme is new, and
+	// codeowners did not run this time.
+	fs := []checks.Finding{
+		{Check: "license", Level: checks.LevelWarn, Path: "/repo/LICENSE", Message: "LICENSE missing"},
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "README.md missing"},
+	}
+	ran := map[string]struct{}{"license": {}, "changelog": {}, "readme": {}}
+	n, stale := recorded.Apply(root, fs, ran)
+	if n != 1 || !fs[0].Suppressed || fs[1].Suppressed {
+		t.Fatalf("expected only the license finding suppressed, got n=%d %+v", n, fs)
+	}
+	if len(stale) != 1 || stale[0].Check != "changelog" {
+		t.Fatalf("expected changelog entry to be stale, got %+v", stale)
+	}
+}
+
+func TestWriteLoadAndMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	first := FromFindings("/repo", []checks.Finding{
+		{Check: "license", Level: checks.LevelWarn, Path: "/repo/LICENSE", Message: "LICENSE missing"},
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "README.md missing"},
+	})
+	if err := Write(path, first); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded.Findings) != 2 || loaded.Version != Version {
+		t.Fatalf("unexpected round trip: %+v", loaded)
+	}
+
+	// Refreshing only the readme check keeps the license entry.
+	next := FromFindings("/repo", nil)
+	merged := Merge(loaded, next, map[string]struct{}{"readme": {}})
+	if len(merged.Findings) != 1 || merged.Findings[0].Check != "license" {
+		t.Fatalf("expected license entry to be carried over, got %+v", merged.Findings)
+	}
+}
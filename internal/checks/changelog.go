@@ -1,9 +1,8 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
+	"context"
+	"path/filepath"
 )
 
 // ChangelogCheck ensures a project includes a CHANGELOG.md file.
@@ -22,17 +21,16 @@ func (ChangelogCheck) Description() string { return "Ensures CHANGELOG.md exists
 
 // Run performs the changelog validation and optional remediation.
 func (ChangelogCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	path := filepath.Join(root, "CHANGELOG.md")
-
 	// If file exists, nothing to report.
-	if _, err := os.Stat(path); err == nil {
+	if exists(opts.fsys(root), "CHANGELOG.md") {
 		return nil, nil
 	}
+	path := filepath.Join(root, "CHANGELOG.md")
 
-    return []Finding{{
-        Check:   "changelog",
-        Level:   LevelWarn,
-        Path:    path,
-        Message: "CHANGELOG.md missing. Add a changelog documenting notable changes (Keep a Changelog format recommended)",
-    }}, nil
+	return []Finding{{
+		Check:   "changelog",
+		Level:   LevelWarn,
+		Path:    path,
+		Message: "CHANGELOG.md missing. Add a changelog documenting notable changes (Keep a Changelog format recommended)",
+	}}, nil
 }
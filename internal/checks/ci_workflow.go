@@ -2,7 +2,7 @@ package checks
 
 import (
 	"context"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
 )
@@ -18,7 +18,7 @@ func (CIWorkflowCheck) Description() string {
 
 func (CIWorkflowCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
 	workflowsDir := filepath.Join(root, ".github", "workflows")
-	entries, err := os.ReadDir(workflowsDir)
+	entries, err := fs.ReadDir(opts.fsys(root), ".github/workflows")
 	if err != nil {
 		return []Finding{{
 			Check:   "ci_workflow",
@@ -2,8 +2,8 @@ package checks
 
 import (
 	"context"
-	"os"
 	"path/filepath"
+	"strings"
 )
 
 // CodeownersCheck ensures a repository defines ownership rules in CODEOWNERS.
@@ -15,14 +15,24 @@ func (CodeownersCheck) Description() string {
 	return "Ensures CODEOWNERS exists in a standard GitHub location"
 }
 
+// defaultCodeownersPaths are the locations GitHub reads CODEOWNERS from.
+var defaultCodeownersPaths = []string{"CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS"}
+
+// Schema declares the settings accepted in the config file.
+func (CodeownersCheck) Schema() []Setting {
+	return []Setting{
+		{Name: "paths", Kind: SettingStringList, Description: "candidate CODEOWNERS locations relative to the repository root"},
+	}
+}
+
 func (CodeownersCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	candidates := []string{
-		filepath.Join(root, "CODEOWNERS"),
-		filepath.Join(root, ".github", "CODEOWNERS"),
-		filepath.Join(root, "docs", "CODEOWNERS"),
+	candidates := opts.Settings.StringList("paths", defaultCodeownersPaths)
+	if len(candidates) == 0 {
+		candidates = defaultCodeownersPaths
 	}
-	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
+	fsys := opts.fsys(root)
+	for _, c := range candidates {
+		if exists(fsys, cleanName(c)) {
 			return nil, nil
 		}
 	}
@@ -30,7 +40,18 @@ func (CodeownersCheck) Run(ctx context.Context, root string, opts Options) ([]Fi
 	return []Finding{{
 		Check:   "codeowners",
 		Level:   LevelWarn,
-		Path:    filepath.Join(root, "CODEOWNERS"),
-		Message: "CODEOWNERS missing. Add ownership rules in CODEOWNERS, .github/CODEOWNERS, or docs/CODEOWNERS",
+		Path:    filepath.Join(root, filepath.FromSlash(candidates[0])),
+		Message: "CODEOWNERS missing. Add ownership rules in " + joinOr(candidates),
 	}}, nil
 }
+
+// joinOr renders a list as "a, b, or c" for finding messages.
+func joinOr(items []string) string {
+	switch len(items) {
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " or " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", or " + items[len(items)-1]
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/hittegit/yardstick/internal/repo"
 )
 
 func TestCodeownersCheck_FindsRootFile(t *testing.T) {
@@ -32,3 +34,49 @@ func TestCodeownersCheck_MissingWarns(t *testing.T) {
 		t.Fatalf("unexpected findings: %+v", fs)
 	}
 }
+
+func TestCodeownersCheck_ConfiguredPaths(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "meta"), 0o750); err != nil {
+		t.Fatalf("mkdir meta: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meta", "OWNERS"), []byte("* @team"), 0o644); err != nil {
+		t.Fatalf("write OWNERS: %v", err)
+	}
+
+	opts := Options{Settings: Settings{"paths": []string{"meta/OWNERS"}}}
+	fs, err := (CodeownersCheck{}).Run(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("expected configured path to satisfy the check, got %+v", fs)
+	}
+}
+
+func TestCodeownersCheck_QueriesIndexNotDisk(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".github", "CODEOWNERS")
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("mkdir .github: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("* @team"), 0o644); err != nil {
+		t.Fatalf("write CODEOWNERS: %v", err)
+	}
+	idx, err := repo.Build(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	// The run sees the tree as indexed, even if the disk changes underneath.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	fs, err := (CodeownersCheck{}).Run(context.Background(), dir, Options{FS: idx})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("expected indexed CODEOWNERS to satisfy the check, got %+v", fs)
+	}
+}
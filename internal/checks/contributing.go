@@ -2,7 +2,6 @@ package checks
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 )
 
@@ -16,12 +15,9 @@ func (ContributingCheck) Description() string {
 }
 
 func (ContributingCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	candidates := []string{
-		filepath.Join(root, "CONTRIBUTING.md"),
-		filepath.Join(root, ".github", "CONTRIBUTING.md"),
-	}
-	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
+	fsys := opts.fsys(root)
+	for _, name := range []string{"CONTRIBUTING.md", ".github/CONTRIBUTING.md"} {
+		if exists(fsys, name) {
 			return nil, nil
 		}
 	}
@@ -0,0 +1,72 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// DartProjectCheck validates baseline conventions for Dart and Flutter
+// projects.
+//
+// Behavior
+//   - No-op when pubspec.yaml is absent.
+//   - Warns when pubspec.lock is missing.
+//   - Warns when pubspec.yaml has no environment.sdk constraint.
+//   - Warns when there is no test/ directory.
+type DartProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (DartProjectCheck) Key() string { return "dart_project" }
+
+// Description provides a short explanation of what this check validates.
+func (DartProjectCheck) Description() string {
+	return "Validates lockfiles, SDK constraints, and test layout for Dart and Flutter projects"
+}
+
+// Run executes the Dart project validation.
+func (DartProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "pubspec.yaml")
+	if err != nil {
+		// Not a Dart project, no-op.
+		return nil, nil
+	}
+	pubspec := filepath.Join(root, "pubspec.yaml")
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "dart_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+	if !exists(fsys, "pubspec.lock") {
+		warn(pubspec, "pubspec.yaml found without pubspec.lock. Run dart pub get and commit pubspec.lock so dependency versions are reproducible")
+	}
+	if !pubspecHasSDK(string(b)) {
+		warn(pubspec, "pubspec.yaml has no environment.sdk constraint. Add one so the supported Dart SDK range is explicit")
+	}
+	if !isDir(fsys, "test") {
+		warn(root, "No test/ directory detected. Add tests under test/ to support CI validation")
+	}
+	return findings, nil
+}
+
+// pubspecHasSDK reports whether the top-level environment mapping sets
+// sdk. pubspec files are plain block YAML, so indentation is enough.
+func pubspecHasSDK(src string) bool {
+	inEnv := false
+	for _, line := range strings.Split(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			inEnv = strings.HasPrefix(trimmed, "environment:")
+			continue
+		}
+		if inEnv && strings.HasPrefix(trimmed, "sdk:") && strings.TrimSpace(strings.TrimPrefix(trimmed, "sdk:")) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package checks
+
+import "testing"
+
+func TestDartProjectCheck_NoPubspec_NoOp(t *testing.T) {
+	if fs := runCheck(t, DartProjectCheck{}, map[string]string{"README.md": ""}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestDartProjectCheck_ConventionalProjectPasses(t *testing.T) {
+	fs := runCheck(t, DartProjectCheck{}, map[string]string{
+		"pubspec.yaml":          "name: demo\n# SDK range\nenvironment:\n  sdk: ^3.4.0\n  flutter: \">=3.22.0\"\n",
+		"pubspec.lock":          "",
+		"test/widget_test.dart": "",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestDartProjectCheck_Rules(t *testing.T) {
+	const pubspec = "name: demo\nenvironment:\n  sdk: ^3.4.0\n"
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"missing pubspec.lock": {
+			files: map[string]string{"pubspec.yaml": pubspec, "test/demo_test.dart": ""},
+			path:  "pubspec.yaml",
+			msg:   "pubspec.yaml found without pubspec.lock. Run dart pub get and commit pubspec.lock so dependency versions are reproducible",
+		},
+		"sdk outside environment": {
+			files: map[string]string{"pubspec.yaml": "name: demo\nenvironment:\ndependencies:\n  flutter:\n    sdk: flutter\n", "pubspec.lock": "", "test/demo_test.dart": ""},
+			path:  "pubspec.yaml",
+			msg:   "pubspec.yaml has no environment.sdk constraint. Add one so the supported Dart SDK range is explicit",
+		},
+		"empty sdk constraint": {
+			files: map[string]string{"pubspec.yaml": "name: demo\nenvironment:\n  sdk:\n", "pubspec.lock": "", "test/demo_test.dart": ""},
+			path:  "pubspec.yaml",
+			msg:   "pubspec.yaml has no environment.sdk constraint. Add one so the supported Dart SDK range is explicit",
+		},
+		"no test directory": {
+			files: map[string]string{"pubspec.yaml": pubspec, "pubspec.lock": "", "integration_test/app_test.dart": ""},
+			path:  ".",
+			msg:   "No test/ directory detected. Add tests under test/ to support CI validation",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, DartProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DotnetProjectCheck validates baseline conventions for .NET projects.
+//
+// Behavior
+//   - No-op unless a root *.sln, *.csproj, *.fsproj, or global.json exists.
+//   - Warns about projects (at the root or listed in a solution) without a
+//     packages.lock.json next to them.
+//   - Warns when global.json does not pin sdk.version.
+//   - Warns when there is no test/ or tests/ directory and no test project.
+type DotnetProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (DotnetProjectCheck) Key() string { return "dotnet_project" }
+
+// Description provides a short explanation of what this check validates.
+func (DotnetProjectCheck) Description() string {
+	return "Validates lockfiles, SDK pinning, and test layout for .NET projects"
+}
+
+var (
+	// slnProject matches project entries in a solution file, for example
+	// Project("{FAE04EC0-...}") = "App", "src\App\App.csproj", "{...}".
+	slnProject = regexp.MustCompile(`(?m)^Project\("[^"]*"\)\s*=\s*"[^"]*",\s*"([^"]+\.[cf]sproj)"`)
+	// dotnetTestProject matches project names that conventionally hold tests.
+	dotnetTestProject = regexp.MustCompile(`(?i)tests?\.[cf]sproj$`)
+)
+
+// Run executes the .NET project validation.
+func (DotnetProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	projects, solutions := dotnetProjects(fsys)
+	hasGlobalJSON := exists(fsys, "global.json")
+	// Not a .NET project, no-op.
+	if len(projects) == 0 && len(solutions) == 0 && !hasGlobalJSON {
+		return nil, nil
+	}
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "dotnet_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+
+	var unlocked []string
+	for _, p := range projects {
+		if !exists(fsys, path.Join(path.Dir(p), "packages.lock.json")) {
+			unlocked = append(unlocked, p)
+		}
+	}
+	if len(unlocked) > 0 {
+		warn(root, "Projects without packages.lock.json: "+strings.Join(unlocked, ", ")+". Set RestorePackagesWithLockFile to true (for example in Directory.Build.props) and commit the lockfiles")
+	}
+
+	globalPath := filepath.Join(root, "global.json")
+	if b, err := fs.ReadFile(fsys, "global.json"); err != nil {
+		warn(globalPath, "global.json missing. Add one with sdk.version so every build uses the same .NET SDK")
+	} else {
+		var g struct {
+			SDK struct {
+				Version string `json:"version"`
+			} `json:"sdk"`
+		}
+		if json.Unmarshal(b, &g) != nil || g.SDK.Version == "" {
+			warn(globalPath, "global.json does not pin sdk.version. Set it so every build uses the same .NET SDK")
+		}
+	}
+
+	hasTestProject := slices.ContainsFunc(projects, dotnetTestProject.MatchString)
+	if !hasTestProject && !isDir(fsys, "test") && !isDir(fsys, "tests") {
+		warn(root, "No .NET test layout detected. Add test projects under test/ or tests/ to support CI validation")
+	}
+	return findings, nil
+}
+
+// dotnetProjects returns the project files at the root and those referenced
+// by root solutions, slash-separated and deduplicated, plus the solutions.
+func dotnetProjects(fsys fs.FS) (projects, solutions []string) {
+	for _, pattern := range []string{"*.csproj", "*.fsproj"} {
+		m, _ := fs.Glob(fsys, pattern)
+		projects = append(projects, m...)
+	}
+	solutions, _ = fs.Glob(fsys, "*.sln")
+	for _, sln := range solutions {
+		b, err := fs.ReadFile(fsys, sln)
+		if err != nil {
+			continue
+		}
+		for _, m := range slnProject.FindAllSubmatch(b, -1) {
+			p := cleanName(strings.ReplaceAll(string(m[1]), `\`, "/"))
+			if !slices.Contains(projects, p) {
+				projects = append(projects, p)
+			}
+		}
+	}
+	return projects, solutions
+}
@@ -0,0 +1,78 @@
+package checks
+
+import "testing"
+
+const demoSolution = `Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App", "src\App\App.csproj", "{11111111-1111-1111-1111-111111111111}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Tests", "src\App.Tests\App.Tests.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "docs", "docs", "{33333333-3333-3333-3333-333333333333}"
+EndProject
+`
+
+func TestDotnetProjectCheck_NoDotnetSignals_NoOp(t *testing.T) {
+	if fs := runCheck(t, DotnetProjectCheck{}, map[string]string{"go.mod": "module x\n"}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestDotnetProjectCheck_SolutionPasses(t *testing.T) {
+	fs := runCheck(t, DotnetProjectCheck{}, map[string]string{
+		"Demo.sln":                         demoSolution,
+		"global.json":                      `{"sdk": {"version": "8.0.400", "rollForward": "latestFeature"}}`,
+		"src/App/App.csproj":               "<Project/>",
+		"src/App/packages.lock.json":       "{}",
+		"src/App.Tests/App.Tests.csproj":   "<Project/>",
+		"src/App.Tests/packages.lock.json": "{}",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestDotnetProjectCheck_Rules(t *testing.T) {
+	const (
+		lockedProject = "<Project Sdk=\"Microsoft.NET.Sdk\">\n  <PropertyGroup>\n    <RestorePackagesWithLockFile>true</RestorePackagesWithLockFile>\n  </PropertyGroup>\n</Project>\n"
+		pinnedSDK     = `{"sdk": {"version": "8.0.400"}}`
+	)
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"lockfile missing with RestorePackagesWithLockFile": {
+			files: map[string]string{"App.csproj": lockedProject, "global.json": pinnedSDK, "tests/App.Tests/App.Tests.csproj": ""},
+			path:  ".",
+			msg:   "Projects without packages.lock.json: App.csproj. Set RestorePackagesWithLockFile to true (for example in Directory.Build.props) and commit the lockfiles",
+		},
+		"solution projects without lockfiles": {
+			files: map[string]string{"Demo.sln": demoSolution, "global.json": pinnedSDK, "src/App/App.csproj": lockedProject, "src/App/packages.lock.json": "{}"},
+			path:  ".",
+			msg:   "Projects without packages.lock.json: src/App.Tests/App.Tests.csproj. Set RestorePackagesWithLockFile to true (for example in Directory.Build.props) and commit the lockfiles",
+		},
+		"global.json absent": {
+			files: map[string]string{"App.Tests.csproj": "<Project/>", "packages.lock.json": "{}"},
+			path:  "global.json",
+			msg:   "global.json missing. Add one with sdk.version so every build uses the same .NET SDK",
+		},
+		"global.json without sdk.version": {
+			files: map[string]string{"App.Tests.csproj": "<Project/>", "packages.lock.json": "{}", "global.json": `{"sdk": {"rollForward": "latestFeature"}, "msbuild-sdks": {}}`},
+			path:  "global.json",
+			msg:   "global.json does not pin sdk.version. Set it so every build uses the same .NET SDK",
+		},
+		"global.json invalid": {
+			files: map[string]string{"App.Tests.csproj": "<Project/>", "packages.lock.json": "{}", "global.json": `{"sdk": {"version": "8.0.400",}}`},
+			path:  "global.json",
+			msg:   "global.json does not pin sdk.version. Set it so every build uses the same .NET SDK",
+		},
+		"no test layout": {
+			files: map[string]string{"App.csproj": lockedProject, "packages.lock.json": "{}", "global.json": pinnedSDK, "docs/readme.md": ""},
+			path:  ".",
+			msg:   "No .NET test layout detected. Add test projects under test/ or tests/ to support CI validation",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, DotnetProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package checks
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Ecosystem is a project ecosystem detected from manifest files at the scan
+// root. A repository can have several, for example a Go backend with a
+// Node web UI.
+type Ecosystem struct {
+	// ID is a stable key such as "go" or "node" for programmatic use.
+	ID string `json:"id"`
+
+	// Name is the human-readable label, for example "Go" or "Static site".
+	Name string `json:"name"`
+
+	// Manifests lists the files that triggered detection, relative to the
+	// scan root with forward slashes.
+	Manifests []string `json:"manifests"`
+}
+
+// ecosystemManifests maps manifest file names to ecosystems. Names may be
+// path.Match patterns such as *.csproj, which report every match. Order is
+// the reporting order, so keep entries for the same ecosystem together.
+var ecosystemManifests = []struct {
+	file string
+	id   string
+	name string
+}{
+	{"go.mod", "go", "Go"},
+	{"package.json", "node", "Node"},
+	{"pyproject.toml", "python", "Python"},
+	{"requirements.txt", "python", "Python"},
+	{"Gemfile", "ruby", "Ruby"},
+	{"Cargo.toml", "rust", "Rust"},
+	{"composer.json", "php", "PHP"},
+	{"pom.xml", "jvm", "Java/Kotlin"},             // Maven
+	{"build.gradle", "jvm", "Java/Kotlin"},        // Gradle
+	{"build.gradle.kts", "jvm", "Java/Kotlin"},    // Gradle Kotlin DSL
+	{"settings.gradle", "jvm", "Java/Kotlin"},     // Gradle multi-project
+	{"settings.gradle.kts", "jvm", "Java/Kotlin"}, // Gradle multi-project, Kotlin DSL
+	{"*.sln", "dotnet", ".NET"},
+	{"*.csproj", "dotnet", ".NET"},
+	{"*.fsproj", "dotnet", ".NET"},
+	{"global.json", "dotnet", ".NET"},
+	{"Package.swift", "swift", "Swift"},
+	{"mix.exs", "elixir", "Elixir"},
+	{"pubspec.yaml", "dart", "Dart"},
+	{"_config.yml", "static_site", "Static site"},  // Jekyll and similar
+	{".eleventy.js", "static_site", "Static site"}, // Eleventy
+	{"mkdocs.yml", "static_site", "Static site"},   // MkDocs
+}
+
+// DetectEcosystems returns every ecosystem with at least one manifest at the
+// root of fsys, in a stable order. It returns an empty, non-nil slice when
+// nothing matches so JSON output always carries a list.
+func DetectEcosystems(fsys fs.FS) []Ecosystem {
+	out := []Ecosystem{}
+	index := make(map[string]int)
+	for _, m := range ecosystemManifests {
+		files := []string{m.file}
+		if strings.ContainsAny(m.file, "*?[") {
+			files, _ = fs.Glob(fsys, m.file)
+		} else if !exists(fsys, m.file) {
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+		i, ok := index[m.id]
+		if !ok {
+			i = len(out)
+			index[m.id] = i
+			out = append(out, Ecosystem{ID: m.id, Name: m.name})
+		}
+		out[i].Manifests = append(out[i].Manifests, files...)
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectEcosystems(t *testing.T) {
+	dir := t.TempDir()
+	if got := DetectEcosystems(os.DirFS(dir)); got == nil || len(got) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %#v", got)
+	}
+
+	for _, name := range []string{"mkdocs.yml", "package.json", "_config.yml", "settings.gradle.kts", "pom.xml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	want := []Ecosystem{
+		{ID: "node", Name: "Node", Manifests: []string{"package.json"}},
+		{ID: "jvm", Name: "Java/Kotlin", Manifests: []string{"pom.xml", "settings.gradle.kts"}},
+		{ID: "static_site", Name: "Static site", Manifests: []string{"_config.yml", "mkdocs.yml"}},
+	}
+	if got := DetectEcosystems(os.DirFS(dir)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("DetectEcosystems = %+v, want %+v", got, want)
+	}
+}
+
+func TestDetectEcosystems_GlobManifests(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Api.csproj", "Demo.sln", "global.json", "Worker.csproj", "pubspec.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	want := []Ecosystem{
+		{ID: "dotnet", Name: ".NET", Manifests: []string{"Demo.sln", "Api.csproj", "Worker.csproj", "global.json"}},
+		{ID: "dart", Name: "Dart", Manifests: []string{"pubspec.yaml"}},
+	}
+	if got := DetectEcosystems(os.DirFS(dir)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("DetectEcosystems = %+v, want %+v", got, want)
+	}
+}
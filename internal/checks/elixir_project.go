@@ -0,0 +1,91 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/toml"
+)
+
+// ElixirProjectCheck validates baseline conventions for Mix projects.
+//
+// Behavior
+//   - No-op when mix.exs is absent.
+//   - Warns when mix.lock is missing.
+//   - Warns when Elixir or Erlang/OTP is not pinned. Either is pinned by
+//     .tool-versions or mise.toml; Elixir also by .elixir-version or the
+//     elixir: requirement in mix.exs.
+//   - Warns when there is no test/ directory.
+type ElixirProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (ElixirProjectCheck) Key() string { return "elixir_project" }
+
+// Description provides a short explanation of what this check validates.
+func (ElixirProjectCheck) Description() string {
+	return "Validates lockfiles, version pinning, and test layout for Elixir projects"
+}
+
+// mixElixirRequirement matches the elixir: version requirement in the
+// project/0 keyword list of mix.exs, e.g. elixir: "~> 1.17".
+var mixElixirRequirement = regexp.MustCompile(`\belixir:\s*"[^"]*\d`)
+
+// Run executes the Mix project validation.
+func (ElixirProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	// Not a Mix project, no-op.
+	if !exists(fsys, "mix.exs") {
+		return nil, nil
+	}
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "elixir_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+	if !exists(fsys, "mix.lock") {
+		warn(filepath.Join(root, "mix.exs"), "mix.exs found without mix.lock. Run mix deps.get and commit mix.lock so dependency versions are reproducible")
+	}
+	mix, _ := fs.ReadFile(fsys, "mix.exs")
+	var unpinned []string
+	if !toolVersionPinned(fsys, "elixir") && !misePinned(fsys, "elixir") && !exists(fsys, ".elixir-version") && !mixElixirRequirement.Match(mix) {
+		unpinned = append(unpinned, "elixir")
+	}
+	if !toolVersionPinned(fsys, "erlang") && !misePinned(fsys, "erlang") {
+		unpinned = append(unpinned, "erlang")
+	}
+	if len(unpinned) > 0 {
+		// Report against the version file in use, or mix.exs when there is
+		// none to edit yet.
+		path, in := filepath.Join(root, "mix.exs"), ""
+		for _, name := range []string{".tool-versions", "mise.toml", ".mise.toml"} {
+			if exists(fsys, name) {
+				path, in = filepath.Join(root, name), " in "+name
+				break
+			}
+		}
+		warn(path, "Versions not pinned"+in+": "+strings.Join(unpinned, ", ")+". Pin Elixir and Erlang/OTP so local and CI runs match")
+	}
+	if !isDir(fsys, "test") {
+		warn(root, "No test/ directory detected. Add ExUnit tests under test/ to support CI validation")
+	}
+	return findings, nil
+}
+
+// misePinned reports whether mise.toml or .mise.toml pins tool in [tools].
+func misePinned(fsys fs.FS, tool string) bool {
+	for _, name := range []string{"mise.toml", ".mise.toml"} {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			continue
+		}
+		if doc, err := toml.Parse(b); err == nil {
+			if _, ok := doc.Root.Get("tools", tool); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package checks
+
+import "testing"
+
+func TestElixirProjectCheck_NoMix_NoOp(t *testing.T) {
+	if fs := runCheck(t, ElixirProjectCheck{}, map[string]string{"README.md": ""}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestElixirProjectCheck_ConventionalProjectPasses(t *testing.T) {
+	fs := runCheck(t, ElixirProjectCheck{}, map[string]string{
+		"mix.exs":              "defmodule Demo.MixProject do\nend\n",
+		"mix.lock":             "%{}\n",
+		".tool-versions":       "erlang 27.0\nelixir 1.17.2-otp-27\n",
+		"test/test_helper.exs": "ExUnit.start()\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestElixirProjectCheck_Rules(t *testing.T) {
+	const (
+		mix      = "defmodule Demo.MixProject do\nend\n"
+		mixReq   = "defmodule Demo.MixProject do\n  def project, do: [app: :demo, elixir: \"~> 1.17\"]\nend\n"
+		pinned   = "erlang 27.0\nelixir 1.17.2-otp-27\n"
+		helper   = "ExUnit.start()\n"
+		unpinMsg = ". Pin Elixir and Erlang/OTP so local and CI runs match"
+	)
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"missing mix.lock": {
+			files: map[string]string{"mix.exs": mix, ".tool-versions": pinned, "test/test_helper.exs": helper},
+			path:  "mix.exs",
+			msg:   "mix.exs found without mix.lock. Run mix deps.get and commit mix.lock so dependency versions are reproducible",
+		},
+		"erlang missing from .tool-versions": {
+			files: map[string]string{"mix.exs": mix, "mix.lock": "", ".tool-versions": "elixir 1.17.2\n", "test/test_helper.exs": helper},
+			path:  ".tool-versions",
+			msg:   "Versions not pinned in .tool-versions: erlang" + unpinMsg,
+		},
+		"both missing from mise.toml": {
+			files: map[string]string{"mix.exs": mix, "mix.lock": "", "mise.toml": "[tools]\nnode = \"20\"\n", "test/test_helper.exs": helper},
+			path:  "mise.toml",
+			msg:   "Versions not pinned in mise.toml: elixir, erlang" + unpinMsg,
+		},
+		"no version file": {
+			files: map[string]string{"mix.exs": mixReq, "mix.lock": "", "test/test_helper.exs": helper},
+			path:  "mix.exs",
+			msg:   "Versions not pinned: erlang" + unpinMsg,
+		},
+		"no test directory": {
+			files: map[string]string{"mix.exs": mix, "mix.lock": "", ".tool-versions": pinned},
+			path:  ".",
+			msg:   "No test/ directory detected. Add ExUnit tests under test/ to support CI validation",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, ElixirProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
+
+func TestElixirProjectCheck_OtherPinSources(t *testing.T) {
+	mix := "defmodule Demo.MixProject do\n  def project do\n    [app: :demo, elixir: \"~> 1.17\"]\n  end\nend\n"
+	for name, files := range map[string]map[string]string{
+		"mix.exs requirement": {"mix.exs": mix, ".tool-versions": "erlang 27.0\n"},
+		"mise.toml":           {"mix.exs": "", "mise.toml": "[tools]\nelixir = \"1.17\"\nerlang = \"27\"\n"},
+		".elixir-version":     {"mix.exs": "", ".elixir-version": "1.17.2\n", ".mise.toml": "[tools]\nerlang = \"27\"\n"},
+	} {
+		files["mix.lock"] = ""
+		files["test/test_helper.exs"] = ""
+		if fs := runCheck(t, ElixirProjectCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected no findings, got:\n%s", name, findingMessages(fs))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/repo"
+)
+
+// fsys returns the tree checks should query: the FS the run supplied, or
+// the live directory at root for callers that only have a path. Names are
+// slash-separated and relative to root, as io/fs requires.
+func (o Options) fsys(root string) fs.FS {
+	if o.FS != nil {
+		return o.FS
+	}
+	return os.DirFS(root)
+}
+
+// onDisk reports whether the tree is the live directory at root, so paths
+// outside it can be read from disk. Archives, -rev commits, and in-memory
+// trees only name root in findings.
+func (o Options) onDisk(root string) bool {
+	switch t := o.FS.(type) {
+	case nil:
+		st, err := os.Stat(root)
+		return err == nil && st.IsDir()
+	case *repo.Index:
+		return t.OnDisk()
+	}
+	return false
+}
+
+// listFiles returns the regular files in fsys in lexical order. A
+// repo.Index answers from the list it built, which leaves out .git and
+// ignored paths; other trees are walked, skipping .git.
+func listFiles(ctx context.Context, fsys fs.FS) ([]string, error) {
+	if idx, ok := fsys.(*repo.Index); ok {
+		return idx.Files(), nil
+	}
+	var names []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == "." {
+				return err
+			}
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return fs.SkipDir
+		}
+		if d.Type().IsRegular() {
+			names = append(names, name)
+		}
+		return nil
+	})
+	return names, err
+}
+
+// exists reports whether name exists in fsys.
+func exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+// isDir reports whether name exists in fsys and is a directory.
+func isDir(fsys fs.FS, name string) bool {
+	st, err := fs.Stat(fsys, name)
+	return err == nil && st.IsDir()
+}
+
+// cleanName turns a user-supplied relative path such as "./docs/" into an
+// io/fs name. Paths cannot climb above the root.
+func cleanName(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
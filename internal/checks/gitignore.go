@@ -1,39 +1,161 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/gitignore"
 )
 
-// GitIgnoreCheck ensures a repository has a .gitignore file with
-// a reasonable starter set of entries. This avoids committing build
-// artifacts, editor settings, and OS files.
+// GitIgnoreCheck ensures a repository has a .gitignore file that covers the
+// essentials for every detected ecosystem. This avoids committing build
+// artifacts, dependency trees, and OS files.
 //
 // Behavior
-//   - If .gitignore exists, no findings are emitted.
-//   - If missing, a warning is reported with guidance.
+//   - If .gitignore is missing, a warning is reported with guidance.
+//   - Otherwise the root .gitignore is matched against representative paths
+//     for each ecosystem (node_modules/ for Node, target/ for Rust, and so
+//     on), and each ecosystem with uncovered paths gets one warning that
+//     lists the lines to add.
 type GitIgnoreCheck struct{}
 
 // Key returns the unique identifier for this check.
 func (GitIgnoreCheck) Key() string { return "gitignore" }
 
 // Description provides a short explanation of what this check validates.
-func (GitIgnoreCheck) Description() string { return ".gitignore includes common entries or guidance is provided if missing" }
+func (GitIgnoreCheck) Description() string {
+	return ".gitignore exists and covers common entries for each detected ecosystem"
+}
+
+// ignoreRule is one entry a .gitignore should cover.
+type ignoreRule struct {
+	// line is the suggested .gitignore line.
+	line string
+	// probes are representative paths the line must ignore. Probes below
+	// the root catch patterns anchored with a leading slash.
+	probes []string
+	dir    bool
+	// manifests limits the rule to ecosystems detected through one of
+	// these files.
+	manifests []string
+	// unless waives the rule when this path exists.
+	unless string
+}
+
+// commonIgnoreRules apply to every repository.
+var commonIgnoreRules = []ignoreRule{
+	{line: ".DS_Store", probes: []string{".DS_Store", "docs/.DS_Store"}},
+}
+
+// ecosystemIgnoreRules are keyed by Ecosystem.ID.
+var ecosystemIgnoreRules = map[string][]ignoreRule{
+	"go": {
+		{line: "*.exe", probes: []string{"app.exe"}},
+		{line: "*.test", probes: []string{"pkg.test"}},
+		{line: "*.out", probes: []string{"coverage.out"}},
+		// Vendoring is a choice, but a stray go mod vendor should not be
+		// committed by accident when the module does not vendor.
+		{line: "vendor/", probes: []string{"vendor"}, dir: true, unless: "vendor/modules.txt"},
+	},
+	"node": {
+		{line: "node_modules/", probes: []string{"node_modules", "packages/app/node_modules"}, dir: true},
+	},
+	"python": {
+		{line: "__pycache__/", probes: []string{"__pycache__", "src/pkg/__pycache__"}, dir: true},
+		{line: ".venv", probes: []string{".venv"}, dir: true},
+	},
+	"ruby": {
+		{line: ".bundle/", probes: []string{".bundle"}, dir: true},
+	},
+	"rust": {
+		{line: "target/", probes: []string{"target"}, dir: true},
+	},
+	"php": {
+		{line: "vendor/", probes: []string{"vendor"}, dir: true},
+	},
+	"dotnet": {
+		{line: "bin/", probes: []string{"bin", "src/App/bin"}, dir: true},
+		{line: "obj/", probes: []string{"obj", "src/App/obj"}, dir: true},
+	},
+	"swift": {
+		{line: ".build/", probes: []string{".build"}, dir: true},
+	},
+	"elixir": {
+		{line: "_build/", probes: []string{"_build"}, dir: true},
+		{line: "deps/", probes: []string{"deps"}, dir: true},
+	},
+	"dart": {
+		{line: ".dart_tool/", probes: []string{".dart_tool"}, dir: true},
+	},
+	"static_site": {
+		{line: "_site/", probes: []string{"_site"}, dir: true, manifests: []string{"_config.yml", ".eleventy.js"}},
+		{line: "site/", probes: []string{"site"}, dir: true, manifests: []string{"mkdocs.yml"}},
+	},
+	"jvm": {
+		{line: "target/", probes: []string{"target"}, dir: true, manifests: []string{"pom.xml"}},
+		{line: ".gradle/", probes: []string{".gradle"}, dir: true, manifests: gradleManifests},
+		{line: "build/", probes: []string{"build", "app/build"}, dir: true, manifests: gradleManifests},
+	},
+}
 
 // Run executes the .gitignore validation.
 func (GitIgnoreCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
 	path := filepath.Join(root, ".gitignore")
+	b, err := fs.ReadFile(fsys, ".gitignore")
+	if err != nil {
+		return []Finding{{
+			Check:   "gitignore",
+			Level:   LevelWarn,
+			Path:    path,
+			Message: ".gitignore missing. Add common ignores for your ecosystem (build artifacts, editor files, OS files)",
+		}}, nil
+	}
+
+	// Only the committed root file counts; .git/info/exclude is local to
+	// one clone and would hide gaps from everyone else.
+	patterns := gitignore.Parse(b)
+	var findings []Finding
+	report := func(label string, rules []ignoreRule, manifests []string) {
+		var missing []string
+		for _, r := range rules {
+			if len(r.manifests) > 0 && !slices.ContainsFunc(r.manifests, func(m string) bool { return slices.Contains(manifests, m) }) {
+				continue
+			}
+			if r.unless != "" && exists(fsys, r.unless) {
+				continue
+			}
+			if slices.Contains(missing, r.line) || covers(patterns, r) {
+				continue
+			}
+			missing = append(missing, r.line)
+		}
+		if len(missing) > 0 {
+			findings = append(findings, Finding{
+				Check:   "gitignore",
+				Level:   LevelWarn,
+				Path:    path,
+				Message: ".gitignore is missing " + label + " entries. Add: " + strings.Join(missing, ", "),
+			})
+		}
+	}
 
-	// If .gitignore already exists, nothing to report.
-	if _, err := os.Stat(path); err == nil {
-		return nil, nil
+	report("common", commonIgnoreRules, nil)
+	for _, e := range DetectEcosystems(fsys) {
+		report(e.Name, ecosystemIgnoreRules[e.ID], e.Manifests)
 	}
+	return findings, nil
+}
 
-    return []Finding{{
-        Check:   "gitignore",
-        Level:   LevelWarn,
-        Path:    path,
-        Message: ".gitignore missing. Add common ignores for your ecosystem (build artifacts, editor files, OS files)",
-    }}, nil
+// covers reports whether patterns ignore every probe of r.
+func covers(patterns []gitignore.Pattern, r ignoreRule) bool {
+	for _, p := range r.probes {
+		if !gitignore.Ignored(patterns, p, r.dir) {
+			return false
+		}
+	}
+	return true
 }
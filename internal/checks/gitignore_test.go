@@ -1,38 +1,122 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
-    "testing"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
 )
 
 func TestGitIgnoreCheck_Missing_ReadOnly(t *testing.T) {
-    dir := t.TempDir()
-    path := filepath.Join(dir, ".gitignore")
-    fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
-    if err != nil {
-        t.Fatalf("run: %v", err)
-    }
-    if len(fs) != 1 || fs[0].Level != LevelWarn {
-        t.Fatalf("expected warn for missing .gitignore, got %+v", fs)
-    }
-    if _, err := os.Stat(path); !os.IsNotExist(err) {
-        t.Fatalf(".gitignore should not be created")
-    }
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gitignore")
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Level != LevelWarn {
+		t.Fatalf("expected warn for missing .gitignore, got %+v", fs)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf(".gitignore should not be created")
+	}
 }
 
 func TestGitIgnoreCheck_Missing_NoWriteEvenWithFix(t *testing.T) {
-    dir := t.TempDir()
-    path := filepath.Join(dir, ".gitignore")
-    fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{AutoFix: true})
-    if err != nil {
-        t.Fatalf("run: %v", err)
-    }
-    if len(fs) != 1 || fs[0].Fixed {
-        t.Fatalf("expected warn finding with Fixed=false, got %+v", fs)
-    }
-    if _, err := os.Stat(path); !os.IsNotExist(err) {
-        t.Fatalf(".gitignore should not be created when AutoFix is true (read-only policy)")
-    }
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gitignore")
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{AutoFix: true})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Fixed {
+		t.Fatalf("expected warn finding with Fixed=false, got %+v", fs)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf(".gitignore should not be created when AutoFix is true (read-only policy)")
+	}
+}
+
+func TestGitIgnoreCheck_CoveredEcosystemsPass(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":       "module example\n",
+		"package.json": "{}",
+		".gitignore":   ".DS_Store\n*.exe\n*.test\n*.out\nvendor/\n**/node_modules\n",
+	})
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v %v", fs, err)
+	}
+}
+
+func TestGitIgnoreCheck_ReportsMissingEntriesPerEcosystem(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pyproject.toml":     "",
+		"requirements.txt":   "",
+		"Cargo.toml":         "",
+		"mkdocs.yml":         "",
+		".git/info/exclude":  ".DS_Store\n",
+		".gitignore":         "/__pycache__/\ntarget/\n",
+		"vendor/modules.txt": "",
+	})
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	var got []string
+	for _, f := range fs {
+		if f.Level != LevelWarn || f.Path != filepath.Join(dir, ".gitignore") {
+			t.Fatalf("unexpected finding: %+v", f)
+		}
+		got = append(got, f.Message)
+	}
+	// Local excludes do not count, and an anchored __pycache__ misses
+	// nested packages.
+	want := []string{
+		".gitignore is missing common entries. Add: .DS_Store",
+		".gitignore is missing Python entries. Add: __pycache__/, .venv",
+		".gitignore is missing Static site entries. Add: site/",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("messages =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGitIgnoreCheck_GoVendorPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":     "module example\n",
+		".gitignore": ".DS_Store\n*.exe\n*.test\n*.out\n",
+	})
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 1 || !strings.HasSuffix(fs[0].Message, "Add: vendor/") {
+		t.Fatalf("expected vendor/ to be required when not vendoring, got %+v %v", fs, err)
+	}
+
+	writeFiles(t, dir, map[string]string{"vendor/modules.txt": ""})
+	fs, err = (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 0 {
+		t.Fatalf("vendored modules should waive vendor/, got %+v %v", fs, err)
+	}
+}
+
+func TestGitIgnoreCheck_JVMRulesFollowBuildTool(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"build.gradle.kts": "",
+		".gitignore":       ".DS_Store\n",
+	})
+	fs, err := (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 1 || fs[0].Message != ".gitignore is missing Java/Kotlin entries. Add: .gradle/, build/" {
+		t.Fatalf("expected Gradle entries only, got %+v %v", fs, err)
+	}
+
+	writeFiles(t, dir, map[string]string{"pom.xml": "", ".gitignore": ".DS_Store\n.gradle\nbuild/\n"})
+	fs, err = (GitIgnoreCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 1 || !strings.HasSuffix(fs[0].Message, "Add: target/") {
+		t.Fatalf("expected Maven target/ entry, got %+v %v", fs, err)
+	}
 }
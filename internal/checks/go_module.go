@@ -0,0 +1,519 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GoModuleCheck validates the hygiene of a Go module rooted at the scan
+// root.
+//
+// Behavior
+//   - No-op when go.mod is absent.
+//   - Warns when go.mod requires modules but go.sum is missing.
+//   - Warns when the go directive is missing or older than the configured
+//     floor (min_go_version, default 1.21).
+//   - Warns when a toolchain line is not a valid toolchain name or is older
+//     than the go directive, and, when the toolchain setting is configured,
+//     when the effective toolchain differs from it.
+//   - Warns about replace directives whose local target lies outside the
+//     repository, since a clean clone cannot build them.
+//   - Warns when vendor/ exists but vendor/modules.txt disagrees with the
+//     requirements in go.mod, or is missing altogether.
+//   - Warns when the module path does not match the origin remote in
+//     .git/config, or a nested module's path does not match its directory.
+type GoModuleCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (GoModuleCheck) Key() string { return "go_module" }
+
+// Description provides a short explanation of what this check validates.
+func (GoModuleCheck) Description() string {
+	return "Validates go.mod, go.sum, vendoring, and module path hygiene for Go modules"
+}
+
+// defaultMinGoVersion is the oldest go directive accepted when none is
+// configured. Go 1.21 made the directive a strict minimum and added
+// toolchain lines.
+const defaultMinGoVersion = "1.21"
+
+// Schema declares the settings accepted in the config file.
+func (GoModuleCheck) Schema() []Setting {
+	return []Setting{
+		{Name: "min_go_version", Kind: SettingString, Description: "oldest go directive accepted, default " + defaultMinGoVersion},
+		{Name: "toolchain", Kind: SettingString, Description: "toolchain go.mod must select, for example go1.26.0; unset accepts any"},
+	}
+}
+
+// Run executes the Go module validation.
+func (GoModuleCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "go.mod")
+	if err != nil {
+		// Not a Go module, no-op.
+		return nil, nil
+	}
+	modFile := filepath.Join(root, "go.mod")
+	mod := parseGoMod(b)
+	var findings []Finding
+	warn := func(line int, msg string) {
+		findings = append(findings, Finding{Check: "go_module", Level: LevelWarn, Path: modFile, Message: msg, Line: line})
+	}
+
+	if len(mod.requires) > 0 && !exists(fsys, "go.sum") {
+		warn(0, "go.mod requires modules but go.sum is missing. Run go mod tidy and commit go.sum")
+	}
+
+	floor := opts.Settings.String("min_go_version", defaultMinGoVersion)
+	switch {
+	case mod.goVersion == "":
+		warn(0, "go.mod has no go directive. Add one with go mod edit -go="+floor)
+	case compareGoVersions(mod.goVersion, floor) < 0:
+		warn(mod.goLine, "go directive "+mod.goVersion+" is older than the minimum "+floor+". Run go mod edit -go="+floor)
+	}
+
+	switch {
+	case mod.toolchain == "":
+	case !strings.HasPrefix(mod.toolchain, "go") || parseGoVersion(toolchainVersion(mod.toolchain)).major == 0:
+		// GOTOOLCHAIN accepts names such as default and local; go.mod does not.
+		warn(mod.toolchainLine, "toolchain "+mod.toolchain+" is not a toolchain name such as go1.22.1, so the go command rejects go.mod. Fix or remove the line")
+	case mod.goVersion != "" && compareGoVersions(toolchainVersion(mod.toolchain), mod.goVersion) < 0:
+		warn(mod.toolchainLine, "toolchain "+mod.toolchain+" is older than go directive "+mod.goVersion+" and is ignored. Remove it or raise it")
+	}
+	if want := opts.Settings.String("toolchain", ""); want != "" {
+		got := mod.toolchain
+		if got == "" && mod.goVersion != "" {
+			// Without a toolchain line the go directive selects the toolchain.
+			got = "go" + mod.goVersion
+		}
+		switch {
+		case got == want:
+		case mod.toolchain == "":
+			warn(0, "go.mod has no toolchain line. Add toolchain "+want)
+		default:
+			warn(mod.toolchainLine, "toolchain "+mod.toolchain+" does not match the expected "+want+". Run go mod edit -toolchain="+want)
+		}
+	}
+
+	for _, r := range mod.replaces {
+		if target, ok := outsideRepo(r.target); ok {
+			warn(r.line, "replace "+r.old+" => "+r.target+" points outside the repository ("+target+"), so clean clones cannot build. Publish the module or move it into the repository")
+		}
+	}
+
+	if msg := vendorDrift(fsys, mod); msg != "" {
+		findings = append(findings, Finding{
+			Check:   "go_module",
+			Level:   LevelWarn,
+			Path:    filepath.Join(root, "vendor"),
+			Message: msg,
+		})
+	}
+
+	if mod.path == "" {
+		warn(0, "go.mod has no module directive. Add module <path> matching the repository's import path")
+		return findings, nil
+	}
+	if remote := originRemote(fsys); remote != "" && !modulePathMatches(mod.path, remote) {
+		warn(mod.pathLine, "module path "+mod.path+" does not match the origin remote "+remote+". Imports of this module will not resolve; use module "+remote)
+	}
+	nested, err := nestedModules(ctx, fsys, mod.path)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nested {
+		findings = append(findings, Finding{
+			Check:   "go_module",
+			Level:   LevelWarn,
+			Path:    filepath.Join(root, filepath.FromSlash(n.dir), "go.mod"),
+			Message: "module path " + n.path + " does not match its directory. Use module " + n.want,
+			Line:    n.line,
+		})
+	}
+	return findings, nil
+}
+
+// goMod is the subset of go.mod yardstick inspects.
+type goMod struct {
+	path          string
+	pathLine      int
+	goVersion     string
+	goLine        int
+	toolchain     string
+	toolchainLine int
+	requires      []goRequire
+	replaces      []goReplace
+}
+
+type goRequire struct {
+	path, version string
+}
+
+type goReplace struct {
+	old, target string
+	line        int
+}
+
+// parseGoMod reads the directives yardstick needs from go.mod, including
+// the block form ("require ( ... )"). Syntax errors are tolerated; the go
+// command reports them better than a check could.
+func parseGoMod(b []byte) goMod {
+	var m goMod
+	block := ""
+	for i, line := range strings.Split(string(b), "\n") {
+		if c := strings.Index(line, "//"); c >= 0 {
+			line = line[:c]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		verb, args := fields[0], fields[1:]
+		switch {
+		case block != "" && verb == ")":
+			block = ""
+			continue
+		case block != "":
+			verb, args = block, fields
+		case len(args) == 1 && args[0] == "(":
+			block = verb
+			continue
+		}
+		for j := range args {
+			args[j] = unquoteModToken(args[j])
+		}
+		switch verb {
+		case "module":
+			if len(args) > 0 {
+				m.path, m.pathLine = args[0], i+1
+			}
+		case "go":
+			if len(args) > 0 {
+				m.goVersion, m.goLine = args[0], i+1
+			}
+		case "toolchain":
+			if len(args) > 0 {
+				m.toolchain, m.toolchainLine = args[0], i+1
+			}
+		case "require":
+			if len(args) >= 2 {
+				m.requires = append(m.requires, goRequire{path: args[0], version: args[1]})
+			}
+		case "replace":
+			for j, a := range args {
+				if a == "=>" && j+1 < len(args) {
+					m.replaces = append(m.replaces, goReplace{old: args[0], target: args[j+1], line: i + 1})
+					break
+				}
+			}
+		}
+	}
+	return m
+}
+
+// unquoteModToken strips the quotes go.mod allows around any token.
+func unquoteModToken(s string) string {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "`") {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
+}
+
+// outsideRepo reports whether a replace target is a local path that
+// escapes the module root, returning it cleaned. Module paths are not
+// local and never match.
+func outsideRepo(target string) (string, bool) {
+	if filepath.IsAbs(target) || strings.HasPrefix(target, "/") {
+		return target, true
+	}
+	if !strings.HasPrefix(target, "./") && !strings.HasPrefix(target, "../") &&
+		!strings.HasPrefix(target, `.\`) && !strings.HasPrefix(target, `..\`) {
+		return "", false
+	}
+	clean := path.Clean(filepath.ToSlash(target))
+	return clean, clean == ".." || strings.HasPrefix(clean, "../")
+}
+
+// toolchainVersion turns a toolchain name such as go1.22.1 or
+// go1.22.1-custom into the version it carries.
+func toolchainVersion(name string) string {
+	v := strings.TrimPrefix(name, "go")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	return v
+}
+
+// goVersion is a parsed Go release version such as 1.21, 1.21rc1, or
+// 1.21.3.
+type goVersion struct {
+	major, minor, patch int
+	// hasPatch distinguishes the language version 1.21 from release 1.21.0.
+	hasPatch bool
+	// kind is "alpha", "beta", or "rc" for prereleases and empty otherwise.
+	kind string
+	pre  int
+}
+
+func parseGoVersion(s string) goVersion {
+	var v goVersion
+	num := func() int {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		n, _ := strconv.Atoi(s[:i])
+		s = s[i:]
+		return n
+	}
+	v.major = num()
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+		v.minor = num()
+	}
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+		v.patch, v.hasPatch = num(), true
+		return v
+	}
+	for _, k := range []string{"alpha", "beta", "rc"} {
+		if strings.HasPrefix(s, k) {
+			s = s[len(k):]
+			v.kind, v.pre = k, num()
+		}
+	}
+	return v
+}
+
+// compareGoVersions orders Go versions the way the go command does:
+// 1.21 < 1.21rc1 < 1.21.0 < 1.21.1 < 1.22.
+func compareGoVersions(a, b string) int {
+	x, y := parseGoVersion(a), parseGoVersion(b)
+	rank := func(v goVersion) [5]int {
+		stage := 0 // language version
+		switch {
+		case v.hasPatch:
+			stage = 4
+		case v.kind == "alpha":
+			stage = 1
+		case v.kind == "beta":
+			stage = 2
+		case v.kind == "rc":
+			stage = 3
+		}
+		return [5]int{v.major, v.minor, stage, v.patch, v.pre}
+	}
+	rx, ry := rank(x), rank(y)
+	for i := range rx {
+		if rx[i] != ry[i] {
+			if rx[i] < ry[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// vendorDrift describes how vendor/ disagrees with go.mod, or returns ""
+// when there is no vendor directory or it is consistent. Like the go
+// command, it compares explicit requirements in both directions and expects
+// every vendored package directory to exist.
+func vendorDrift(fsys fs.FS, mod goMod) string {
+	if !isDir(fsys, "vendor") {
+		return ""
+	}
+	b, err := fs.ReadFile(fsys, "vendor/modules.txt")
+	if err != nil {
+		if exists(fsys, "composer.json") {
+			// PHP's vendor/ directory, not Go's.
+			return ""
+		}
+		return "vendor/ exists without vendor/modules.txt, so go builds fail with inconsistent vendoring. Run go mod vendor or remove vendor/"
+	}
+
+	// versions holds every module header; explicit marks those go.mod
+	// requires directly, which are the ones the go command compares.
+	versions := map[string]string{}
+	explicit := map[string]bool{}
+	var problems []string
+	module := ""
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "## "):
+			if module != "" && strings.HasPrefix(line, "## explicit") {
+				explicit[module] = true
+			}
+		case strings.HasPrefix(line, "# "):
+			f := strings.Fields(line[2:])
+			module = ""
+			if len(f) >= 2 && f[1] != "=>" {
+				module = f[0]
+				versions[module] = f[1]
+			}
+		case line != "" && module != "":
+			if !isDir(fsys, "vendor/"+line) {
+				problems = append(problems, line+" listed but not vendored")
+			}
+		}
+	}
+
+	required := map[string]bool{}
+	for _, r := range mod.requires {
+		required[r.path] = true
+		switch v := versions[r.path]; {
+		case !explicit[r.path]:
+			problems = append(problems, r.path+" "+r.version+" required but not vendored")
+		case v != r.version:
+			problems = append(problems, r.path+" "+r.version+" required but "+v+" vendored")
+		}
+	}
+	for p := range explicit {
+		if !required[p] {
+			problems = append(problems, p+" vendored but not required")
+		}
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	slices.Sort(problems)
+	if len(problems) > 5 {
+		problems = append(problems[:5], fmt.Sprintf("%d more", len(problems)-5))
+	}
+	return "vendor/modules.txt is out of sync with go.mod (" + strings.Join(problems, "; ") + "). Run go mod vendor"
+}
+
+// originRemote returns the origin remote from .git/config as an import
+// path prefix such as github.com/owner/repo, or "" when there is none.
+func originRemote(fsys fs.FS) string {
+	b, err := fs.ReadFile(fsys, ".git/config")
+	if err != nil {
+		return ""
+	}
+	inOrigin := false
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inOrigin = strings.EqualFold(strings.Join(strings.Fields(line), " "), `[remote "origin"]`)
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if inOrigin && ok && strings.TrimSpace(key) == "url" {
+			return remoteImportPath(strings.TrimSpace(val))
+		}
+	}
+	return ""
+}
+
+// remoteImportPath normalizes https://host/owner/repo.git,
+// ssh://git@host/owner/repo, and git@host:owner/repo to host/owner/repo.
+// Local paths return "".
+func remoteImportPath(url string) string {
+	if scheme, rest, ok := strings.Cut(url, "://"); ok {
+		if scheme == "file" {
+			return ""
+		}
+		url = rest
+	} else if i := strings.Index(url, ":"); i > 1 && !strings.Contains(url[:i], "/") {
+		url = url[:i] + "/" + url[i+1:]
+	} else {
+		return ""
+	}
+	if at := strings.Index(url, "@"); at >= 0 && at < strings.Index(url+"/", "/") {
+		url = url[at+1:]
+	}
+	host, rest, _ := strings.Cut(url, "/")
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		// Drop ports; they are not part of import paths.
+		host = h
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git")
+	if host == "" || rest == "" {
+		return ""
+	}
+	return strings.ToLower(host) + "/" + rest
+}
+
+// modulePathMatches reports whether a root module path agrees with the
+// origin remote. Vanity paths on another host are accepted, since the
+// remote cannot tell us what they should be.
+func modulePathMatches(modPath, remote string) bool {
+	host, _, _ := strings.Cut(remote, "/")
+	modHost, _, _ := strings.Cut(modPath, "/")
+	if !strings.EqualFold(modHost, host) {
+		return true
+	}
+	base, _ := splitMajor(modPath)
+	return strings.EqualFold(base, remote)
+}
+
+// splitMajor splits a /vN major version suffix (N >= 2) off a module path.
+func splitMajor(modPath string) (base, major string) {
+	i := strings.LastIndex(modPath, "/v")
+	if i < 0 {
+		return modPath, ""
+	}
+	n, err := strconv.Atoi(modPath[i+2:])
+	if err != nil || n < 2 || strconv.Itoa(n) != modPath[i+2:] {
+		return modPath, ""
+	}
+	return modPath[:i], modPath[i:]
+}
+
+// nestedModule is a go.mod below the root whose module path does not
+// follow the directory layout.
+type nestedModule struct {
+	dir, path, want string
+	line            int
+}
+
+// nestedModules finds go.mod files below the root and returns those whose
+// module path is not the root path (without its major version suffix)
+// joined with their directory. Directories the go command ignores are
+// skipped, along with dependency trees.
+func nestedModules(ctx context.Context, fsys fs.FS, rootPath string) ([]nestedModule, error) {
+	names, err := listFiles(ctx, fsys)
+	if err != nil {
+		return nil, err
+	}
+	base, _ := splitMajor(rootPath)
+	var out []nestedModule
+	for _, name := range names {
+		dir, file := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+		if file != "go.mod" || dir == "" || goIgnoredDir(dir) {
+			continue
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			continue
+		}
+		m := parseGoMod(b)
+		want := base + "/" + dir
+		if got, _ := splitMajor(m.path); m.path != "" && got != want {
+			out = append(out, nestedModule{dir: dir, path: m.path, want: want, line: m.pathLine})
+		}
+	}
+	return out, nil
+}
+
+// goIgnoredDir reports whether the go command ignores dir, or any directory
+// above it, as a package tree: hidden and underscore directories, testdata,
+// and dependency trees.
+func goIgnoredDir(dir string) bool {
+	for _, n := range strings.Split(dir, "/") {
+		if strings.HasPrefix(n, ".") || strings.HasPrefix(n, "_") || n == "testdata" || n == "vendor" || n == "node_modules" {
+			return true
+		}
+	}
+	return false
+}
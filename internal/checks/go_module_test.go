@@ -0,0 +1,209 @@
+package checks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hittegit/yardstick/internal/repo"
+)
+
+func TestGoModuleCheck_NoGoMod_NoOp(t *testing.T) {
+	if fs := runCheck(t, GoModuleCheck{}, map[string]string{"README.md": "# x\n"}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_HealthyModulePasses(t *testing.T) {
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod":          "module github.com/acme/tool\n\ngo 1.22\n\nrequire (\n\texample.com/a v1.0.0 // indirect\n)\n\nreplace example.com/a => ./third_party/a\n",
+		"go.sum":          "",
+		".git/config":     "[remote \"origin\"]\n\turl = git@github.com:acme/tool.git\n",
+		"tools/go.mod":    "module github.com/acme/tool/tools\n\ngo 1.22\n",
+		"testdata/go.mod": "module whatever\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_MissingGoSum(t *testing.T) {
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod": "module example\n\ngo 1.22\n\nrequire example.com/a v1.0.0\n",
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "go.sum is missing") {
+		t.Fatalf("expected go.sum finding, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_GoDirectiveFloor(t *testing.T) {
+	files := map[string]string{"go.mod": "module example\n\ngo 1.18\n"}
+	fs := runCheck(t, GoModuleCheck{}, files)
+	if len(fs) != 1 || fs[0].Line != 3 || !strings.Contains(fs[0].Message, "older than the minimum 1.21") {
+		t.Fatalf("expected go directive finding on line 3, got %+v", fs)
+	}
+	if fs := runCheckOpts(t, GoModuleCheck{}, files, Options{Settings: Settings{"min_go_version": "1.17"}}); len(fs) != 0 {
+		t.Fatalf("expected configured floor to accept 1.18, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_Toolchain(t *testing.T) {
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod": "module example\n\ngo 1.22.1\n\ntoolchain go1.22.0\n",
+	})
+	if len(fs) != 1 || fs[0].Line != 5 || !strings.Contains(fs[0].Message, "older than go directive") {
+		t.Fatalf("expected stale toolchain finding, got %+v", fs)
+	}
+	fs = runCheck(t, GoModuleCheck{}, map[string]string{"go.mod": "module example\n\ngo 1.22\n\ntoolchain default\n"})
+	if len(fs) != 1 || fs[0].Line != 5 || !strings.Contains(fs[0].Message, "toolchain default is not a toolchain name") {
+		t.Fatalf("expected invalid toolchain finding, got %+v", fs)
+	}
+
+	want := Settings{"toolchain": "go1.26.0"}
+	if fs := runCheckOpts(t, GoModuleCheck{}, map[string]string{"go.mod": "module example\n\ngo 1.26.0\n"}, Options{Settings: want}); len(fs) != 0 {
+		t.Fatalf("go directive should select the expected toolchain, got %+v", fs)
+	}
+	fs = runCheckOpts(t, GoModuleCheck{}, map[string]string{"go.mod": "module example\n\ngo 1.22\n"}, Options{Settings: want})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "no toolchain line") {
+		t.Fatalf("expected missing toolchain finding, got %+v", fs)
+	}
+	fs = runCheckOpts(t, GoModuleCheck{}, map[string]string{"go.mod": "module example\n\ngo 1.22\ntoolchain go1.25.1\n"}, Options{Settings: want})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "does not match the expected go1.26.0") {
+		t.Fatalf("expected toolchain mismatch finding, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_ReplaceOutsideRepo(t *testing.T) {
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod": "module example\n\ngo 1.22\n\nreplace (\n\texample.com/a v1.0.0 => ../a\n\texample.com/b => ./internal/../b\n\texample.com/c => example.com/c v1.2.0\n)\n",
+	})
+	if len(fs) != 1 || fs[0].Line != 6 || !strings.Contains(fs[0].Message, "example.com/a => ../a") {
+		t.Fatalf("expected one replace finding on line 6, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_VendorDrift(t *testing.T) {
+	gomod := "module example\n\ngo 1.22\n\nrequire (\n\texample.com/a v1.1.0\n\texample.com/b v1.0.0\n)\n"
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod": gomod,
+		"go.sum": "",
+		"vendor/modules.txt": "# example.com/a v1.0.0\n## explicit\nexample.com/a\n" +
+			"# example.com/c v0.1.0\n## explicit; go 1.20\nexample.com/c\n",
+		"vendor/example.com/a/a.go": "package a\n",
+	})
+	if len(fs) != 1 {
+		t.Fatalf("expected one vendor finding, got %+v", fs)
+	}
+	for _, want := range []string{
+		"example.com/a v1.1.0 required but v1.0.0 vendored",
+		"example.com/b v1.0.0 required but not vendored",
+		"example.com/c vendored but not required",
+		"example.com/c listed but not vendored",
+	} {
+		if !strings.Contains(fs[0].Message, want) {
+			t.Fatalf("expected %q in %q", want, fs[0].Message)
+		}
+	}
+
+	fs = runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod":                    gomod,
+		"go.sum":                    "",
+		"vendor/modules.txt":        "# example.com/a v1.1.0\n## explicit\nexample.com/a\n# example.com/b v1.0.0\n## explicit\n",
+		"vendor/example.com/a/a.go": "package a\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected consistent vendor tree to pass, got %+v", fs)
+	}
+
+	fs = runCheck(t, GoModuleCheck{}, map[string]string{"go.mod": "module example\n\ngo 1.22\n", "vendor/x/x.go": "package x\n"})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "without vendor/modules.txt") {
+		t.Fatalf("expected missing modules.txt finding, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_ModulePathLayout(t *testing.T) {
+	fs := runCheck(t, GoModuleCheck{}, map[string]string{
+		"go.mod":        "module github.com/acme/old-name/v2\n\ngo 1.22\n",
+		".git/config":   "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = https://github.com/acme/new-name.git\n",
+		"sub/go.mod":    "module github.com/acme/old-name/v2/sub\n",
+		"api/go.mod":    "module github.com/acme/old-name/api/v3\n",
+		"vanity/go.mod": "module example.com/vanity\n",
+	})
+	var msgs []string
+	for _, f := range fs {
+		msgs = append(msgs, f.Message)
+	}
+	got := strings.Join(msgs, "\n")
+	if len(fs) != 3 ||
+		!strings.Contains(got, "does not match the origin remote github.com/acme/new-name") ||
+		!strings.Contains(got, "module path github.com/acme/old-name/v2/sub does not match its directory. Use module github.com/acme/old-name/sub") ||
+		!strings.Contains(got, "module path example.com/vanity does not match its directory") {
+		t.Fatalf("unexpected findings:\n%s", got)
+	}
+}
+
+func TestGoModuleCheck_ScansGivenFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example\n\nrequire example.com/a v1.0.0\n")},
+	}
+	fs, err := (GoModuleCheck{}).Run(context.Background(), "repo", Options{FS: fsys})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 2 || fs[0].Path != "repo/go.mod" {
+		t.Fatalf("expected go.sum and go directive findings, got %+v", fs)
+	}
+}
+
+func TestGoModuleCheck_SkipsIgnoredNestedModules(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.26\n",
+		".gitignore":       "/build/\n",
+		"build/tmp/go.mod": "module scratch\n",
+	})
+	idx, err := repo.Build(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	fs, err := (GoModuleCheck{}).Run(context.Background(), dir, Options{FS: idx})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("expected ignored go.mod to be skipped, got:\n%s", findingMessages(fs))
+	}
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	ordered := []string{"1.9", "1.20", "1.21", "1.21rc1", "1.21rc2", "1.21.0", "1.21.3", "1.22"}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := compareGoVersions(ordered[i], ordered[j]); got != want {
+				t.Fatalf("compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestRemoteImportPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://github.com/acme/tool.git":  "github.com/acme/tool",
+		"ssh://git@GitHub.com:22/acme/tool": "github.com/acme/tool",
+		"git@gitlab.com:group/sub/tool.git": "gitlab.com/group/sub/tool",
+		"https://user@example.org/x/y/":     "example.org/x/y",
+		"/srv/git/tool.git":                 "",
+		"file:///srv/git/tool.git":          "",
+	} {
+		if got := remoteImportPath(in); got != want {
+			t.Errorf("remoteImportPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -13,11 +13,55 @@ var guidanceByCheck = map[string]Guidance{
 	},
 	"javascript_framework": {
 		WhyImportant: "Framework conventions improve build reliability and reduce CI/runtime surprises across JavaScript projects.",
-		HowToResolve: "Add the expected package scripts, and for Next.js ensure app/ or pages/ exists with scripts.dev/build/start configured.",
+		HowToResolve: "Add dev, build, test, and lint package scripts, and the layout each framework expects: app/ or pages/ plus scripts.start for Next.js, angular.json for Angular, svelte.config.js and src/routes for SvelteKit, nuxt.config.* for Nuxt, vite.config.* and index.html for Vite, app/routes for Remix, and gatsby-config.* for Gatsby.",
+	},
+	"node_package_manager": {
+		WhyImportant: "A missing lockfile, lockfiles from several package managers, or an unpinned Node version lets CI install different dependencies than developers tested with.",
+		HowToResolve: "Commit exactly one lockfile, make packageManager (and pnpm-workspace.yaml, if any) match it, and pin Node with engines.node, .nvmrc, or .node-version.",
+	},
+	"typescript": {
+		WhyImportant: "A missing or broken tsconfig.json, or one without strict mode, lets type errors reach production and makes editor and CI type checking disagree.",
+		HowToResolve: "Commit a tsconfig.json that enables strict (directly or via extends), point extends at files or installed packages, and keep include and files entries matching real sources.",
 	},
 	"python_project": {
-		WhyImportant: "Python project conventions make dependency management and CI test execution more predictable.",
-		HowToResolve: "Add pyproject.toml and ensure a test layout/config exists, for example tests/, pytest.ini, tox.ini, or noxfile.py.",
+		WhyImportant: "Python project conventions make dependency management and CI test execution more predictable, and complete pyproject.toml metadata lets installers build the project and reject incompatible interpreters.",
+		HowToResolve: "Add pyproject.toml with [build-system] and PEP 621 [project] metadata (name, version, requires-python, license, readme), commit the lockfile of the tool in use, and ensure a test layout/config exists, for example tests/, pytest.ini, tox.ini, or noxfile.py.",
+	},
+	"go_module": {
+		WhyImportant: "An inconsistent go.mod, go.sum, or vendor/ tree breaks builds from a clean clone and lets dependency and toolchain versions drift silently.",
+		HowToResolve: "Run go mod tidy (and go mod vendor when vendoring), commit go.sum, raise the go directive to the configured floor, keep replace targets inside the repository, and make module paths match the remote and directory layout.",
+	},
+	"rust_project": {
+		WhyImportant: "Cargo metadata pins the language edition and minimum Rust version, a committed lockfile keeps binaries reproducible, and crates.io needs license and repository details.",
+		HowToResolve: "Set edition and rust-version (directly or via [workspace.package]), commit Cargo.lock for binaries, add license and repository or publish = false, and fix workspace members that do not exist.",
+	},
+	"ruby_project": {
+		WhyImportant: "A committed Gemfile.lock and pinned Ruby version keep installs reproducible, a test layout lets CI run the suite, and gem metadata tells users what they are installing.",
+		HowToResolve: "Commit Gemfile.lock, add .ruby-version or a ruby directive in the Gemfile, add spec/ or test/, and set summary, license, homepage, and required_ruby_version in the gemspec.",
+	},
+	"php_project": {
+		WhyImportant: "A committed composer.lock keeps installs reproducible, complete composer.json metadata and valid autoload paths keep the package loadable, and PHPUnit config lets CI run the tests.",
+		HowToResolve: "Commit composer.lock, add name, license, and PSR-4 autoload entries that point at existing directories, and add tests/ with phpunit.xml or phpunit.xml.dist.",
+	},
+	"jvm_project": {
+		WhyImportant: "Committed Maven or Gradle wrappers pin the build tool version for every developer and CI runner, a distribution checksum stops tampered downloads, and src/test lets CI run the tests.",
+		HowToResolve: "Run gradle wrapper or mvn wrapper:wrapper and commit the scripts and properties, set distributionSha256Sum in the wrapper properties, and add tests under src/test.",
+	},
+	"dotnet_project": {
+		WhyImportant: "NuGet lockfiles and a pinned SDK in global.json keep restores and builds identical across machines, and test projects let CI validate changes.",
+		HowToResolve: "Enable RestorePackagesWithLockFile and commit each packages.lock.json, pin sdk.version in global.json, and add test projects under test/ or tests/.",
+	},
+	"swift_project": {
+		WhyImportant: "A committed Package.resolved and a swift-tools-version line keep dependency and toolchain versions reproducible, and test targets let CI validate changes.",
+		HowToResolve: "Commit Package.resolved, start Package.swift with // swift-tools-version:<version>, and add test targets under Tests/.",
+	},
+	"elixir_project": {
+		WhyImportant: "A committed mix.lock and pinned Elixir and Erlang/OTP versions keep builds reproducible, and ExUnit tests let CI validate changes.",
+		HowToResolve: "Commit mix.lock, pin elixir and erlang in .tool-versions or mise.toml (an elixir: requirement in mix.exs or .elixir-version also pins Elixir), and add tests under test/.",
+	},
+	"dart_project": {
+		WhyImportant: "A committed pubspec.lock and an explicit SDK constraint keep dependency resolution reproducible, and tests let CI validate changes.",
+		HowToResolve: "Commit pubspec.lock, set environment.sdk in pubspec.yaml, and add tests under test/.",
 	},
 	"static_site": {
 		WhyImportant: "A minimal static-site structure improves reliability for builds, hosting, and navigation.",
@@ -37,7 +81,11 @@ var guidanceByCheck = map[string]Guidance{
 	},
 	"gitignore": {
 		WhyImportant: "A .gitignore prevents accidental commits of build artifacts, secrets, and machine-local files.",
-		HowToResolve: "Add a .gitignore tuned to your stack to exclude artifacts, editor files, and OS-specific files.",
+		HowToResolve: "Add a .gitignore tuned to your stack, including the lines each finding lists, to exclude artifacts, dependency trees, and OS-specific files.",
+	},
+	"tracked_files": {
+		WhyImportant: "Adding a pattern to .gitignore does not untrack files already committed, so artifacts and secrets keep shipping with every clone.",
+		HowToResolve: "Untrack ignored paths with git rm --cached (-r for directories) and commit, then commit or ignore any remaining untracked files.",
 	},
 	"changelog": {
 		WhyImportant: "A changelog helps users and maintainers track behavior changes across releases.",
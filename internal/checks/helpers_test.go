@@ -0,0 +1,71 @@
+package checks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files, keyed by slash-separated path, under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, body := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+// runCheck writes files into a fresh directory and runs c against it.
+func runCheck(t *testing.T, c Check, files map[string]string) []Finding {
+	t.Helper()
+	return runCheckOpts(t, c, files, Options{})
+}
+
+// runCheckOpts is runCheck with explicit options, such as settings.
+func runCheckOpts(t *testing.T, c Check, files map[string]string, opts Options) []Finding {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+	fs, err := c.Run(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return fs
+}
+
+// findingMessages joins finding messages one per line for failure output.
+func findingMessages(fs []Finding) string {
+	var msgs []string
+	for _, f := range fs {
+		msgs = append(msgs, f.Message)
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// expectFinding runs c against files and fails unless it reports exactly
+// one finding, at path relative to the scanned root, with message msg.
+func expectFinding(t *testing.T, c Check, files map[string]string, path, msg string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+	fs, err := c.Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 {
+		t.Fatalf("expected one finding, got:\n%s", findingMessages(fs))
+	}
+	if want := filepath.Join(dir, filepath.FromSlash(path)); fs[0].Path != want {
+		t.Errorf("path: got %s, want %s", fs[0].Path, want)
+	}
+	if fs[0].Message != msg {
+		t.Errorf("message:\n got %s\nwant %s", fs[0].Message, msg)
+	}
+}
@@ -3,13 +3,17 @@ package checks
 import (
 	"context"
 	"encoding/json"
-	"os"
+	"errors"
+	"io/fs"
 	"path/filepath"
 	"slices"
+	"strings"
 )
 
 // JavaScriptFrameworkCheck validates baseline structure for JavaScript framework projects.
-// It applies broad checks for framework projects and adds Next.js-specific compatibility checks.
+// It applies broad checks for framework projects (dev, build, test, and lint
+// scripts) and per-framework layout rules, such as angular.json for Angular or
+// src/routes for SvelteKit, plus Next.js-specific compatibility checks.
 type JavaScriptFrameworkCheck struct{}
 
 func (JavaScriptFrameworkCheck) Key() string { return "javascript_framework" }
@@ -25,26 +29,32 @@ type packageJSON struct {
 }
 
 func (JavaScriptFrameworkCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
 	pkgPath := filepath.Join(root, "package.json")
 	// Not a JS project, no-op.
-	if _, err := os.Stat(pkgPath); err != nil {
+	if !exists(fsys, "package.json") {
 		return nil, nil
 	}
 
-	// #nosec G304 -- root/path are intentionally user-selected scan targets.
-	b, err := os.ReadFile(pkgPath)
+	b, err := fs.ReadFile(fsys, "package.json")
 	if err != nil {
 		return nil, err
 	}
 
 	var pkg packageJSON
 	if err := json.Unmarshal(b, &pkg); err != nil {
-		return []Finding{{
+		f := Finding{
 			Check:   "javascript_framework",
 			Level:   LevelWarn,
 			Path:    pkgPath,
 			Message: "package.json is not valid JSON. Fix JSON syntax so framework checks can run reliably",
-		}}, nil
+		}
+		// Offset counts the bytes read including the offending one.
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			f.Line, f.Column = position(string(b), int(syn.Offset)-1)
+		}
+		return []Finding{f}, nil
 	}
 
 	frameworks := detectJavaScriptFrameworks(pkg)
@@ -71,22 +81,44 @@ func (JavaScriptFrameworkCheck) Run(ctx context.Context, root string, opts Optio
 		})
 	}
 
-	// Explicit Next.js compatibility checks.
-	if slices.Contains(frameworks, "next") {
-		if !hasScript(pkg.Scripts, "start") {
+	names := javaScriptFrameworkNames(frameworks)
+	for _, script := range []string{"test", "lint"} {
+		if !hasScript(pkg.Scripts, script) || script == "test" && npmTestPlaceholder(pkg.Scripts["test"]) {
 			findings = append(findings, Finding{
 				Check:   "javascript_framework",
 				Level:   LevelWarn,
 				Path:    pkgPath,
-				Message: "Next.js project missing scripts.start. Add a start script for runtime compatibility",
+				Message: names + " project missing scripts." + script + " in package.json. Add a " + script + " script so CI can run it",
 			})
 		}
-		if !hasDir(filepath.Join(root, "app")) && !hasDir(filepath.Join(root, "pages")) {
+	}
+
+	// Explicit Next.js compatibility checks.
+	if slices.Contains(frameworks, "next") && !hasScript(pkg.Scripts, "start") {
+		findings = append(findings, Finding{
+			Check:   "javascript_framework",
+			Level:   LevelWarn,
+			Path:    pkgPath,
+			Message: "Next.js project missing scripts.start. Add a start script for runtime compatibility",
+		})
+	}
+
+	// Per-framework project layout.
+	for _, fw := range javaScriptFrameworks {
+		if !slices.Contains(frameworks, fw.dep) || fw.dep == "vite" && len(frameworks) > 1 {
+			// Vite is also the build tool of most other frameworks, which
+			// bring their own layout instead of index.html.
+			continue
+		}
+		for _, req := range fw.layout {
+			if slices.ContainsFunc(req.anyOf, func(p string) bool { return globExists(fsys, p, req.dir) }) {
+				continue
+			}
 			findings = append(findings, Finding{
 				Check:   "javascript_framework",
 				Level:   LevelWarn,
 				Path:    root,
-				Message: "Next.js project missing both app/ and pages/. Add at least one routing directory",
+				Message: req.message,
 			})
 		}
 	}
@@ -94,26 +126,87 @@ func (JavaScriptFrameworkCheck) Run(ctx context.Context, root string, opts Optio
 	return findings, nil
 }
 
+// frameworkRequirement is one file or directory a framework expects,
+// satisfied by any of its alternatives (glob patterns allowed).
+type frameworkRequirement struct {
+	anyOf   []string
+	dir     bool
+	message string
+}
+
+// javaScriptFrameworks lists the recognized frameworks by dependency, in
+// detection order, with a display name and expected layout.
+var javaScriptFrameworks = []struct {
+	dep    string
+	name   string
+	layout []frameworkRequirement
+}{
+	{dep: "next", name: "Next.js", layout: []frameworkRequirement{
+		{anyOf: []string{"app", "pages", "src/app", "src/pages"}, dir: true, message: "Next.js project missing both app/ and pages/. Add at least one routing directory"},
+	}},
+	{dep: "react-scripts", name: "Create React App"},
+	{dep: "vite", name: "Vite", layout: []frameworkRequirement{
+		{anyOf: []string{"vite.config.*"}, message: "Vite project missing vite.config.*. Add vite.config.ts or vite.config.js so builds are configured explicitly"},
+		{anyOf: []string{"index.html"}, message: "Vite project missing index.html. Add the index.html entry point Vite serves and builds from"},
+	}},
+	{dep: "nuxt", name: "Nuxt", layout: []frameworkRequirement{
+		{anyOf: []string{"nuxt.config.*"}, message: "Nuxt project missing nuxt.config.*. Add nuxt.config.ts to configure the app"},
+	}},
+	{dep: "@angular/core", name: "Angular", layout: []frameworkRequirement{
+		{anyOf: []string{"angular.json"}, message: "Angular project missing angular.json. Add the workspace configuration the Angular CLI builds from"},
+	}},
+	{dep: "@sveltejs/kit", name: "SvelteKit", layout: []frameworkRequirement{
+		{anyOf: []string{"svelte.config.js", "svelte.config.ts"}, message: "SvelteKit project missing svelte.config.js. Add it to configure the adapter and preprocessors"},
+		{anyOf: []string{"src/routes"}, dir: true, message: "SvelteKit project missing src/routes/. Add the routes directory SvelteKit builds pages from"},
+	}},
+	{dep: "gatsby", name: "Gatsby", layout: []frameworkRequirement{
+		{anyOf: []string{"gatsby-config.*"}, message: "Gatsby project missing gatsby-config.*. Add gatsby-config.js or gatsby-config.ts to configure the site"},
+	}},
+	{dep: "@remix-run/react", name: "Remix", layout: []frameworkRequirement{
+		{anyOf: []string{"app/routes"}, dir: true, message: "Remix project missing app/routes/. Add the routes directory Remix builds pages from"},
+	}},
+}
+
 func detectJavaScriptFrameworks(pkg packageJSON) []string {
-	frameworkDeps := []string{
-		"next",
-		"react-scripts",
-		"vite",
-		"nuxt",
-		"@angular/core",
-		"@sveltejs/kit",
-		"gatsby",
-		"@remix-run/react",
-	}
 	var frameworks []string
-	for _, dep := range frameworkDeps {
-		if hasDep(pkg.Dependencies, dep) || hasDep(pkg.DevDependencies, dep) {
-			frameworks = append(frameworks, dep)
+	for _, fw := range javaScriptFrameworks {
+		if hasDep(pkg.Dependencies, fw.dep) || hasDep(pkg.DevDependencies, fw.dep) {
+			frameworks = append(frameworks, fw.dep)
 		}
 	}
 	return frameworks
 }
 
+// javaScriptFrameworkNames renders detected frameworks for messages,
+// leaving out Vite when it only builds another framework.
+func javaScriptFrameworkNames(frameworks []string) string {
+	var names []string
+	for _, fw := range javaScriptFrameworks {
+		if slices.Contains(frameworks, fw.dep) && (fw.dep != "vite" || len(frameworks) == 1) {
+			names = append(names, fw.name)
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// npmTestPlaceholder reports whether a test script is the stub npm init
+// writes, which always fails.
+func npmTestPlaceholder(script string) bool {
+	return strings.Contains(script, "no test specified")
+}
+
+// globExists reports whether any entry matching pattern exists, and is a
+// directory when dir is set.
+func globExists(fsys fs.FS, pattern string, dir bool) bool {
+	matches, _ := fs.Glob(fsys, pattern)
+	for _, m := range matches {
+		if !dir || isDir(fsys, m) {
+			return true
+		}
+	}
+	return false
+}
+
 func hasScript(scripts map[string]string, key string) bool {
 	if scripts == nil {
 		return false
@@ -129,8 +222,3 @@ func hasDep(deps map[string]string, name string) bool {
 	_, ok := deps[name]
 	return ok
 }
-
-func hasDir(path string) bool {
-	st, err := os.Stat(path)
-	return err == nil && st.IsDir()
-}
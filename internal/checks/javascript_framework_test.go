@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -25,7 +26,9 @@ func TestJavaScriptFrameworkCheck_NextJSValid_NoFindings(t *testing.T) {
   "scripts": {
     "dev": "next dev",
     "build": "next build",
-    "start": "next start"
+    "start": "next start",
+    "test": "vitest run",
+    "lint": "next lint"
   }
 }`
 	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0o644); err != nil {
@@ -60,8 +63,9 @@ func TestJavaScriptFrameworkCheck_NextJSMissingConventions_Warns(t *testing.T) {
 	if err != nil {
 		t.Fatalf("run: %v", err)
 	}
-	if len(fs) != 3 {
-		t.Fatalf("expected 3 findings, got %d (%+v)", len(fs), fs)
+	// build, test, lint, start, and a routing directory.
+	if len(fs) != 5 {
+		t.Fatalf("expected 5 findings, got %d (%+v)", len(fs), fs)
 	}
 	for _, f := range fs {
 		if f.Check != "javascript_framework" || f.Level != LevelWarn {
@@ -75,12 +79,16 @@ func TestJavaScriptFrameworkCheck_GeneralFrameworkMissingBuild_Warns(t *testing.
 	pkg := `{
   "devDependencies": {"vite": "^5.0.0"},
   "scripts": {
-    "dev": "vite"
+    "dev": "vite",
+    "test": "vitest run",
+    "lint": "eslint ."
   }
 }`
-	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0o644); err != nil {
-		t.Fatalf("write package.json: %v", err)
-	}
+	writeFiles(t, dir, map[string]string{
+		"package.json":   pkg,
+		"vite.config.ts": "export default {}\n",
+		"index.html":     "<!doctype html>\n",
+	})
 
 	fs, err := (JavaScriptFrameworkCheck{}).Run(context.Background(), dir, Options{})
 	if err != nil {
@@ -93,3 +101,87 @@ func TestJavaScriptFrameworkCheck_GeneralFrameworkMissingBuild_Warns(t *testing.
 		t.Fatalf("expected non-empty message")
 	}
 }
+
+func TestJavaScriptFrameworkCheck_InvalidJSONReportsPosition(t *testing.T) {
+	dir := t.TempDir()
+	pkg := "{\n  \"scripts\": {\n    \"dev\": \"next dev\",\n  }\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0o644); err != nil {
+		t.Fatalf("write package.json: %v", err)
+	}
+	fs, err := (JavaScriptFrameworkCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Line != 4 || fs[0].Column != 3 {
+		t.Fatalf("expected finding at 4:3, got %+v", fs)
+	}
+}
+
+func TestJavaScriptFrameworkCheck_FrameworkLayouts(t *testing.T) {
+	scripts := `"scripts": {"dev": "x", "build": "x", "test": "x", "lint": "x"}`
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "Angular",
+			files: map[string]string{"package.json": `{"dependencies": {"@angular/core": "^18"}, ` + scripts + `}`},
+			want:  []string{"Angular project missing angular.json"},
+		},
+		{
+			name:  "SvelteKit on Vite",
+			files: map[string]string{"package.json": `{"devDependencies": {"@sveltejs/kit": "^2", "vite": "^5"}, ` + scripts + `}`},
+			want:  []string{"SvelteKit project missing svelte.config.js", "SvelteKit project missing src/routes/"},
+		},
+		{
+			name: "SvelteKit complete",
+			files: map[string]string{
+				"package.json":            `{"devDependencies": {"@sveltejs/kit": "^2", "vite": "^5"}, ` + scripts + `}`,
+				"svelte.config.js":        "",
+				"src/routes/+page.svelte": "",
+			},
+		},
+		{
+			name:  "Nuxt",
+			files: map[string]string{"package.json": `{"dependencies": {"nuxt": "^3"}, ` + scripts + `}`, "nuxt.config.ts": ""},
+		},
+		{
+			name:  "Vite",
+			files: map[string]string{"package.json": `{"devDependencies": {"vite": "^5"}, ` + scripts + `}`},
+			want:  []string{"Vite project missing vite.config.*", "Vite project missing index.html"},
+		},
+		{
+			name:  "Remix",
+			files: map[string]string{"package.json": `{"dependencies": {"@remix-run/react": "^2"}, ` + scripts + `}`, "app/routes": ""},
+			want:  []string{"Remix project missing app/routes/"},
+		},
+		{
+			name:  "Gatsby",
+			files: map[string]string{"package.json": `{"dependencies": {"gatsby": "^5"}, ` + scripts + `}`, "gatsby-config.mjs": ""},
+		},
+		{
+			name:  "test and lint scripts",
+			files: map[string]string{"package.json": `{"dependencies": {"nuxt": "^3"}, "scripts": {"dev": "x", "build": "x", "test": "echo \"Error: no test specified\" && exit 1"}}`, "nuxt.config.ts": ""},
+			want:  []string{"Nuxt project missing scripts.test", "Nuxt project missing scripts.lint"},
+		},
+	}
+	for _, tc := range cases {
+		dir := t.TempDir()
+		writeFiles(t, dir, tc.files)
+		fs, err := (JavaScriptFrameworkCheck{}).Run(context.Background(), dir, Options{})
+		if err != nil {
+			t.Fatalf("%s: run: %v", tc.name, err)
+		}
+		got := findingMessages(fs)
+		if len(fs) != len(tc.want) {
+			t.Errorf("%s: expected %d findings, got:\n%s", tc.name, len(tc.want), got)
+			continue
+		}
+		for _, w := range tc.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%s: expected %q in:\n%s", tc.name, w, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// JVMProjectCheck validates baseline conventions for Java and Kotlin
+// projects built with Maven or Gradle.
+//
+// Behavior
+//   - No-op unless pom.xml or a Gradle build or settings script exists.
+//   - Warns when the build tool's wrapper scripts (gradlew/gradlew.bat or
+//     mvnw/mvnw.cmd) or wrapper properties are missing, so builds do not
+//     depend on whatever version is installed.
+//   - Warns when a wrapper jar is committed but the wrapper properties do
+//     not pin distributionSha256Sum, since the downloaded distribution is
+//     then never verified.
+//   - Warns when neither the root nor any module declared in pom.xml or
+//     settings.gradle has a src/test directory.
+type JVMProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (JVMProjectCheck) Key() string { return "jvm_project" }
+
+// Description provides a short explanation of what this check validates.
+func (JVMProjectCheck) Description() string {
+	return "Validates baseline conventions for Java/Kotlin projects built with Maven or Gradle"
+}
+
+// gradleManifests are the files that mark a Gradle build.
+var gradleManifests = []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}
+
+// jvmWrapper describes one build tool's wrapper layout.
+type jvmWrapper struct {
+	tool       string
+	scripts    []string
+	properties string
+	jar        string
+	generate   string
+}
+
+var (
+	gradleWrapper = jvmWrapper{
+		tool:       "Gradle",
+		scripts:    []string{"gradlew", "gradlew.bat"},
+		properties: "gradle/wrapper/gradle-wrapper.properties",
+		jar:        "gradle/wrapper/gradle-wrapper.jar",
+		generate:   "gradle wrapper",
+	}
+	mavenWrapper = jvmWrapper{
+		tool:       "Maven",
+		scripts:    []string{"mvnw", "mvnw.cmd"},
+		properties: ".mvn/wrapper/maven-wrapper.properties",
+		jar:        ".mvn/wrapper/maven-wrapper.jar",
+		generate:   "mvn wrapper:wrapper",
+	}
+
+	// pomModule matches <module> entries of a Maven aggregator POM.
+	pomModule = regexp.MustCompile(`<module>\s*([^<\s]+)\s*</module>`)
+	// gradleInclude matches include statements in Gradle settings scripts.
+	gradleInclude = regexp.MustCompile(`(?m)^\s*include\b(.*)$`)
+	// gradleQuotedArg matches the single- or double-quoted project paths of
+	// an include statement.
+	gradleQuotedArg = regexp.MustCompile(`["']([^"']+)["']`)
+)
+
+// Run executes the JVM project validation.
+func (JVMProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	hasMaven := exists(fsys, "pom.xml")
+	hasGradle := false
+	for _, m := range gradleManifests {
+		hasGradle = hasGradle || exists(fsys, m)
+	}
+	// Not a JVM project, no-op.
+	if !hasMaven && !hasGradle {
+		return nil, nil
+	}
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "jvm_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+
+	var wrappers []jvmWrapper
+	if hasGradle {
+		wrappers = append(wrappers, gradleWrapper)
+	}
+	if hasMaven {
+		wrappers = append(wrappers, mavenWrapper)
+	}
+	for _, w := range wrappers {
+		var missing []string
+		for _, s := range w.scripts {
+			if !exists(fsys, s) {
+				missing = append(missing, s)
+			}
+		}
+		if len(missing) > 0 {
+			warn(root, w.tool+" wrapper scripts missing: "+strings.Join(missing, ", ")+". Run "+w.generate+" and commit the scripts so builds use a pinned "+w.tool+" version")
+		}
+		props, err := fs.ReadFile(fsys, w.properties)
+		if err != nil {
+			warn(filepath.Join(root, filepath.FromSlash(w.properties)), w.tool+" wrapper properties missing. Run "+w.generate+" to pin the distribution the wrapper downloads")
+			continue
+		}
+		if exists(fsys, w.jar) && javaProperty(props, "distributionSha256Sum") == "" {
+			warn(filepath.Join(root, filepath.FromSlash(w.properties)), w.tool+" wrapper jar is committed but distributionSha256Sum is not set. Pin the distribution checksum so downloads are verified")
+		}
+	}
+
+	if !hasJVMTests(fsys) {
+		warn(root, "No src/test directory detected in the project or its modules. Add tests under src/test so CI can run them")
+	}
+	return findings, nil
+}
+
+// hasJVMTests reports whether the root or any declared module has src/test.
+func hasJVMTests(fsys fs.FS) bool {
+	if isDir(fsys, "src/test") {
+		return true
+	}
+	for _, m := range jvmModules(fsys) {
+		if isDir(fsys, m+"/src/test") {
+			return true
+		}
+	}
+	return false
+}
+
+// jvmModules lists module directories declared by pom.xml <module> entries
+// and Gradle include statements, where ":lib:core" maps to lib/core.
+func jvmModules(fsys fs.FS) []string {
+	var dirs []string
+	if b, err := fs.ReadFile(fsys, "pom.xml"); err == nil {
+		for _, m := range pomModule.FindAllSubmatch(b, -1) {
+			dirs = append(dirs, cleanName(string(m[1])))
+		}
+	}
+	for _, name := range []string{"settings.gradle", "settings.gradle.kts"} {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			continue
+		}
+		for _, inc := range gradleInclude.FindAllSubmatch(b, -1) {
+			for _, q := range gradleQuotedArg.FindAllSubmatch(inc[1], -1) {
+				dirs = append(dirs, cleanName(strings.ReplaceAll(strings.TrimPrefix(string(q[1]), ":"), ":", "/")))
+			}
+		}
+	}
+	return dirs
+}
+
+// javaProperty returns the value of key in a .properties file, accepting
+// both = and : separators.
+func javaProperty(b []byte, key string) string {
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			continue
+		}
+		if strings.TrimSpace(line[:i]) == key {
+			return strings.TrimSpace(line[i+1:])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package checks
+
+import "testing"
+
+func TestJVMProjectCheck_NoJVMSignals_NoOp(t *testing.T) {
+	if fs := runCheck(t, JVMProjectCheck{}, map[string]string{"go.mod": "module x\n"}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestJVMProjectCheck_GradleMultiProjectPasses(t *testing.T) {
+	fs := runCheck(t, JVMProjectCheck{}, map[string]string{
+		"settings.gradle.kts":               "rootProject.name = \"svc\"\ninclude(\":app\", \":lib:core\")\n",
+		"gradlew":                           "#!/bin/sh\n",
+		"gradlew.bat":                       "@echo off\n",
+		"gradle/wrapper/gradle-wrapper.jar": "",
+		"gradle/wrapper/gradle-wrapper.properties": "distributionUrl=https\\://services.gradle.org/distributions/gradle-8.10-bin.zip\n" +
+			"distributionSha256Sum=5b9c5eb3f9fc2c94abaea57d90bd78747ca117ddbbf96c859d3741181a12bf2a\n",
+		"lib/core/src/test/java/CoreTest.java": "",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestJVMProjectCheck_Rules(t *testing.T) {
+	const (
+		gradleProps = "distributionUrl=https\\://services.gradle.org/distributions/gradle-8.10-bin.zip\n"
+		mavenProps  = "distributionUrl=https://repo.maven.apache.org/maven2/org/apache/maven/apache-maven/3.9.9/apache-maven-3.9.9-bin.zip\n"
+	)
+	gradle := func(without ...string) map[string]string {
+		files := map[string]string{
+			"build.gradle": "plugins { id 'java' }\n",
+			"gradlew":      "#!/bin/sh\n",
+			"gradlew.bat":  "@echo off\n",
+			"gradle/wrapper/gradle-wrapper.properties": gradleProps,
+			"src/test/java/AppTest.java":               "",
+		}
+		for _, name := range without {
+			delete(files, name)
+		}
+		return files
+	}
+	maven := func(without ...string) map[string]string {
+		files := map[string]string{
+			"pom.xml":                               "<project><modules>\n  <module>service</module>\n</modules></project>\n",
+			"mvnw":                                  "",
+			"mvnw.cmd":                              "",
+			".mvn/wrapper/maven-wrapper.properties": mavenProps,
+			"service/src/test/java/AppTest.java":    "",
+		}
+		for _, name := range without {
+			delete(files, name)
+		}
+		return files
+	}
+	with := func(files map[string]string, name string) map[string]string {
+		files[name] = ""
+		return files
+	}
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"Gradle wrapper scripts": {
+			files: gradle("gradlew.bat"),
+			path:  ".",
+			msg:   "Gradle wrapper scripts missing: gradlew.bat. Run gradle wrapper and commit the scripts so builds use a pinned Gradle version",
+		},
+		"Gradle wrapper properties": {
+			files: gradle("gradle/wrapper/gradle-wrapper.properties"),
+			path:  "gradle/wrapper/gradle-wrapper.properties",
+			msg:   "Gradle wrapper properties missing. Run gradle wrapper to pin the distribution the wrapper downloads",
+		},
+		"Gradle wrapper jar without checksum": {
+			files: with(gradle(), "gradle/wrapper/gradle-wrapper.jar"),
+			path:  "gradle/wrapper/gradle-wrapper.properties",
+			msg:   "Gradle wrapper jar is committed but distributionSha256Sum is not set. Pin the distribution checksum so downloads are verified",
+		},
+		"Maven wrapper scripts": {
+			files: maven("mvnw", "mvnw.cmd"),
+			path:  ".",
+			msg:   "Maven wrapper scripts missing: mvnw, mvnw.cmd. Run mvn wrapper:wrapper and commit the scripts so builds use a pinned Maven version",
+		},
+		"Maven wrapper properties": {
+			files: maven(".mvn/wrapper/maven-wrapper.properties"),
+			path:  ".mvn/wrapper/maven-wrapper.properties",
+			msg:   "Maven wrapper properties missing. Run mvn wrapper:wrapper to pin the distribution the wrapper downloads",
+		},
+		"Maven wrapper jar without checksum": {
+			files: with(maven(), ".mvn/wrapper/maven-wrapper.jar"),
+			path:  ".mvn/wrapper/maven-wrapper.properties",
+			msg:   "Maven wrapper jar is committed but distributionSha256Sum is not set. Pin the distribution checksum so downloads are verified",
+		},
+		"tests outside declared modules": {
+			files: with(maven("service/src/test/java/AppTest.java"), "other/src/test/java/AppTest.java"),
+			path:  ".",
+			msg:   "No src/test directory detected in the project or its modules. Add tests under src/test so CI can run them",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, JVMProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
+
+func TestJavaProperty(t *testing.T) {
+	props := []byte("# comment\n! also comment\nkey = value\nother: x=y\n")
+	for key, want := range map[string]string{"key": "value", "other": "x=y", "missing": ""} {
+		if got := javaProperty(props, key); got != want {
+			t.Errorf("javaProperty(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -1,9 +1,8 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
+	"context"
+	"path/filepath"
 )
 
 // LicenseCheck ensures that a LICENSE file is present in the repository.
@@ -28,17 +27,16 @@ func (LicenseCheck) Description() string {
 //   - If missing, a warning is emitted.
 //   - If --fix is used, a default MIT license is created.
 func (LicenseCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	path := filepath.Join(root, "LICENSE")
-
 	// If file already exists, nothing to report.
-	if _, err := os.Stat(path); err == nil {
+	if exists(opts.fsys(root), "LICENSE") {
 		return nil, nil
 	}
+	path := filepath.Join(root, "LICENSE")
 
-    return []Finding{{
-        Check:   "license",
-        Level:   LevelWarn,
-        Path:    path,
-        Message: "LICENSE missing. Add a license file (e.g., MIT, Apache-2.0) appropriate to your project",
-    }}, nil
+	return []Finding{{
+		Check:   "license",
+		Level:   LevelWarn,
+		Path:    path,
+		Message: "LICENSE missing. Add a license file (e.g., MIT, Apache-2.0) appropriate to your project",
+	}}, nil
 }
@@ -2,25 +2,29 @@ package checks
 
 import (
 	"context"
-	"os"
 	"path/filepath"
+	"strings"
 )
 
-// ManifestCheck attempts to detect a project's primary ecosystem by looking
-// for common manifest files. It is intentionally neutral so yardstick can be
-// used across Go, Node, Python, Ruby, Rust, and static site projects.
+// ManifestCheck detects a project's ecosystems by looking for common
+// manifest files. It is intentionally neutral so yardstick can be
+// used across Go, Node, Python, Ruby, Rust, PHP, JVM, .NET, Swift,
+// Elixir, Dart, and static site projects.
 //
 // Behavior
-//   - If a known manifest is found, emit an info finding stating which
-//     ecosystem was detected and which file triggered it.
+//   - For every ecosystem with a known manifest, emit an info finding
+//     stating which ecosystem was detected and which files triggered it.
+//     A Go backend with a Node web UI reports both.
 //   - If no known manifests are found, emit a warn finding suggesting the
 //     user add an appropriate manifest for their stack.
 //   - This check does not auto-create manifests, since that choice is
 //     project specific and harder to do safely.
 //
 // Extending detection
-//   - Add new entries to the candidates slice with the filename and a short
-//     label. Keep the check simple and fast.
+//   - Add new entries to ecosystemManifests (ecosystem.go) with the
+//     filename (or a glob such as *.csproj), a stable id, and a short
+//     label. Keep detection simple and fast. DetectEcosystems exposes the
+//     same data to other checks and the JSON report.
 //   - If needed later, we can add per-ecosystem subchecks, for example
 //     NodeLockfileCheck, PythonVenvCheck, etc.
 //
@@ -31,47 +35,37 @@ import (
 //   - Ruby:       Gemfile
 //   - Rust:       Cargo.toml
 //   - PHP:        composer.json
+//   - JVM:        pom.xml, build.gradle(.kts), or settings.gradle(.kts)
+//   - .NET:       *.sln, *.csproj, *.fsproj, or global.json
+//   - Swift:      Package.swift
+//   - Elixir:     mix.exs
+//   - Dart:       pubspec.yaml
 //   - Static:     _config.yml, .eleventy.js, mkdocs.yml
 //   - Docs only:  README.md without a manifest will still pass other checks
-//                 but this one will warn.
+//     but this one will warn.
 type ManifestCheck struct{}
 
 // Key returns the unique identifier for this check.
 func (ManifestCheck) Key() string { return "manifest" }
 
 // Description provides a short explanation of what this check validates.
-func (ManifestCheck) Description() string { return "Detects project ecosystem by scanning for common manifests" }
+func (ManifestCheck) Description() string {
+	return "Detects project ecosystem by scanning for common manifests"
+}
 
-// Run performs the manifest detection logic.
+// Run reports one info finding per detected ecosystem.
 func (ManifestCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	// Known manifest candidates and a friendly label for reporting.
-	type candidate struct {
-		name  string
-		label string
-	}
-	candidates := []candidate{
-		{"go.mod", "Go"},
-		{"package.json", "Node"},
-		{"pyproject.toml", "Python"},
-		{"requirements.txt", "Python"},
-		{"Gemfile", "Ruby"},
-		{"Cargo.toml", "Rust"},
-		{"composer.json", "PHP"},
-		{"_config.yml", "Static site"},      // Jekyll and similar
-		{".eleventy.js", "Static site"},      // Eleventy
-		{"mkdocs.yml", "Static site"},        // MkDocs
+	var findings []Finding
+	for _, e := range DetectEcosystems(opts.fsys(root)) {
+		findings = append(findings, Finding{
+			Check:   "manifest",
+			Level:   LevelInfo,
+			Path:    filepath.Join(root, filepath.FromSlash(e.Manifests[0])),
+			Message: e.Name + " project detected via " + strings.Join(e.Manifests, ", "),
+		})
 	}
-
-	for _, c := range candidates {
-		p := filepath.Join(root, c.name)
-		if _, err := os.Stat(p); err == nil {
-			return []Finding{{
-				Check:   "manifest",
-				Level:   LevelInfo,
-				Path:    p,
-				Message: c.label + " project detected via " + c.name,
-			}}, nil
-		}
+	if len(findings) > 0 {
+		return findings, nil
 	}
 
 	// Nothing matched. Suggest adding a manifest appropriate to the stack.
@@ -79,6 +73,6 @@ func (ManifestCheck) Run(ctx context.Context, root string, opts Options) ([]Find
 		Check:   "manifest",
 		Level:   LevelWarn,
 		Path:    root,
-		Message: "No common project manifest found. Expected one of: go.mod, package.json, pyproject.toml, requirements.txt, Gemfile, Cargo.toml, composer.json, or a static site config",
+		Message: "No common project manifest found. Expected one of: go.mod, package.json, pyproject.toml, requirements.txt, Gemfile, Cargo.toml, composer.json, pom.xml, build.gradle, *.csproj, *.sln, Package.swift, mix.exs, pubspec.yaml, or a static site config",
 	}}, nil
 }
@@ -0,0 +1,125 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// NodePackageManagerCheck validates that a Node project uses one package
+// manager consistently, so installs in CI match local ones.
+//
+// Behavior
+//   - No-op when package.json is absent or does not parse (the
+//     javascript_framework check reports syntax errors).
+//   - Detects the package manager from the packageManager field, lockfiles,
+//     and pnpm-workspace.yaml.
+//   - Warns when there is no lockfile, or lockfiles from several managers.
+//   - Warns when packageManager or pnpm-workspace.yaml disagrees with the
+//     lockfile present.
+//   - Warns when the Node version is pinned by neither engines.node nor
+//     .nvmrc, .node-version, or .tool-versions.
+type NodePackageManagerCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (NodePackageManagerCheck) Key() string { return "node_package_manager" }
+
+// Description provides a short explanation of what this check validates.
+func (NodePackageManagerCheck) Description() string {
+	return "Validates Node lockfiles, packageManager, and Node version pinning are consistent"
+}
+
+// nodeLockfiles maps lockfiles to the package manager that writes them, in
+// reporting order.
+var nodeLockfiles = []struct {
+	file    string
+	manager string
+}{
+	{"package-lock.json", "npm"},
+	{"npm-shrinkwrap.json", "npm"},
+	{"yarn.lock", "yarn"},
+	{"pnpm-lock.yaml", "pnpm"},
+	{"bun.lock", "bun"},
+	{"bun.lockb", "bun"},
+}
+
+// Run executes the package manager validation.
+func (NodePackageManagerCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "package.json")
+	if err != nil {
+		// Not a Node project, no-op.
+		return nil, nil
+	}
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+		Engines        struct {
+			Node string `json:"node"`
+		} `json:"engines"`
+	}
+	if json.Unmarshal(b, &pkg) != nil {
+		return nil, nil
+	}
+	pkgPath := filepath.Join(root, "package.json")
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "node_package_manager", Level: LevelWarn, Path: path, Message: msg})
+	}
+
+	var lockfiles, managers []string
+	for _, l := range nodeLockfiles {
+		if exists(fsys, l.file) {
+			lockfiles = append(lockfiles, l.file)
+			if !slices.Contains(managers, l.manager) {
+				managers = append(managers, l.manager)
+			}
+		}
+	}
+	declared, _, _ := strings.Cut(pkg.PackageManager, "@")
+	declared = strings.TrimSpace(declared)
+
+	switch {
+	case len(lockfiles) == 0:
+		hint := "npm install"
+		switch {
+		case declared != "":
+			hint = declared + " install"
+		case exists(fsys, "pnpm-workspace.yaml"):
+			hint = "pnpm install"
+		}
+		warn(pkgPath, "No lockfile found. Run "+hint+" and commit the lockfile so CI installs the same dependency versions")
+	case len(managers) > 1:
+		warn(root, "Lockfiles from several package managers: "+strings.Join(lockfiles, ", ")+". Keep only the lockfile of the package manager the project uses")
+	}
+
+	if declared != "" && len(managers) == 1 && declared != managers[0] {
+		f := Finding{
+			Check:   "node_package_manager",
+			Level:   LevelWarn,
+			Path:    pkgPath,
+			Message: "packageManager declares " + pkg.PackageManager + " but the lockfile is " + lockfiles[0] + " (" + managers[0] + "). Align packageManager with the lockfile, or switch lockfiles",
+		}
+		if i := strings.Index(string(b), `"packageManager"`); i >= 0 {
+			f = withSpan(f, string(b), i, i+len(`"packageManager"`))
+		}
+		findings = append(findings, f)
+	}
+	if exists(fsys, "pnpm-workspace.yaml") {
+		foreign := declared != "" && declared != "pnpm"
+		for _, m := range managers {
+			foreign = foreign || m != "pnpm"
+		}
+		if foreign {
+			warn(filepath.Join(root, "pnpm-workspace.yaml"), "pnpm-workspace.yaml is present but the project is set up for another package manager. Use pnpm throughout, or remove pnpm-workspace.yaml")
+		}
+	}
+
+	if strings.TrimSpace(pkg.Engines.Node) == "" && !exists(fsys, ".nvmrc") && !exists(fsys, ".node-version") && !toolVersionPinned(fsys, "nodejs") && !toolVersionPinned(fsys, "node") {
+		warn(pkgPath, "Node version is not pinned. Set engines.node in package.json or add .nvmrc or .node-version so local and CI runs use the same Node")
+	}
+	return findings, nil
+}
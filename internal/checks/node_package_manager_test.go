@@ -0,0 +1,93 @@
+package checks
+
+import "testing"
+
+func TestNodePackageManagerCheck_NoPackageJSON_NoOp(t *testing.T) {
+	if fs := runCheck(t, NodePackageManagerCheck{}, map[string]string{"yarn.lock": ""}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+	if fs := runCheck(t, NodePackageManagerCheck{}, map[string]string{"package.json": "{"}); len(fs) != 0 {
+		t.Fatalf("invalid package.json is left to javascript_framework, got %+v", fs)
+	}
+}
+
+func TestNodePackageManagerCheck_ConsistentProjectsPass(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"npm": {
+			"package.json":      `{"engines": {"node": ">=20"}}`,
+			"package-lock.json": "{}",
+		},
+		"pnpm workspace": {
+			"package.json":        `{"packageManager": "pnpm@9.12.0+sha512.abc"}`,
+			"pnpm-lock.yaml":      "",
+			"pnpm-workspace.yaml": "packages: ['apps/*']\n",
+			".nvmrc":              "20\n",
+		},
+		"yarn": {
+			"package.json":   `{"packageManager": "yarn@4.5.0"}`,
+			"yarn.lock":      "",
+			".tool-versions": "nodejs 20.17.0\n",
+		},
+	} {
+		if fs := runCheck(t, NodePackageManagerCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected no findings, got %+v", name, fs)
+		}
+	}
+}
+
+func TestNodePackageManagerCheck_Rules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"no lockfile": {
+			files: map[string]string{"package.json": `{"engines": {"node": "20"}}`},
+			path:  "package.json",
+			msg:   "No lockfile found. Run npm install and commit the lockfile so CI installs the same dependency versions",
+		},
+		"no lockfile with declared manager": {
+			files: map[string]string{"package.json": `{"packageManager": "yarn@4.5.0"}`, ".nvmrc": "20\n"},
+			path:  "package.json",
+			msg:   "No lockfile found. Run yarn install and commit the lockfile so CI installs the same dependency versions",
+		},
+		"no lockfile in pnpm workspace": {
+			files: map[string]string{"package.json": `{}`, "pnpm-workspace.yaml": "", ".node-version": "20\n"},
+			path:  "package.json",
+			msg:   "No lockfile found. Run pnpm install and commit the lockfile so CI installs the same dependency versions",
+		},
+		"lockfiles from several managers": {
+			files: map[string]string{"package.json": `{"engines": {"node": "20"}}`, "package-lock.json": "{}", "yarn.lock": ""},
+			path:  ".",
+			msg:   "Lockfiles from several package managers: package-lock.json, yarn.lock. Keep only the lockfile of the package manager the project uses",
+		},
+		"packageManager disagrees with lockfile": {
+			files: map[string]string{"package.json": `{"engines": {"node": "20"}, "packageManager": "yarn@1.22.22"}`, "package-lock.json": "{}"},
+			path:  "package.json",
+			msg:   "packageManager declares yarn@1.22.22 but the lockfile is package-lock.json (npm). Align packageManager with the lockfile, or switch lockfiles",
+		},
+		"pnpm workspace with npm lockfile": {
+			files: map[string]string{"package.json": `{"engines": {"node": "20"}}`, "package-lock.json": "{}", "pnpm-workspace.yaml": ""},
+			path:  "pnpm-workspace.yaml",
+			msg:   "pnpm-workspace.yaml is present but the project is set up for another package manager. Use pnpm throughout, or remove pnpm-workspace.yaml",
+		},
+		"unpinned Node": {
+			files: map[string]string{"package.json": `{"engines": {"node": " "}}`, "package-lock.json": "{}", ".tool-versions": "python 3.12\n"},
+			path:  "package.json",
+			msg:   "Node version is not pinned. Set engines.node in package.json or add .nvmrc or .node-version so local and CI runs use the same Node",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, NodePackageManagerCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
+
+func TestNodePackageManagerCheck_PackageManagerPosition(t *testing.T) {
+	fs := runCheck(t, NodePackageManagerCheck{}, map[string]string{
+		"package.json":      "{\n  \"engines\": {\"node\": \"20\"},\n  \"packageManager\": \"yarn@1.22.22\"\n}\n",
+		"package-lock.json": "{}",
+	})
+	if len(fs) != 1 || fs[0].Line != 3 || fs[0].Column != 3 {
+		t.Fatalf("expected packageManager position 3:3, got %+v", fs)
+	}
+}
@@ -0,0 +1,150 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// PHPProjectCheck validates baseline conventions for Composer-based PHP
+// projects.
+//
+// Behavior
+//   - No-op when composer.json is absent.
+//   - Warns when composer.json is not valid JSON, with the error position.
+//   - Warns when composer.lock is missing.
+//   - Warns when name, license, or autoload is missing, and when a PSR-4
+//     autoload directory (autoload or autoload-dev) does not exist.
+//   - Warns when there is no tests/ directory or PHPUnit configuration.
+type PHPProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (PHPProjectCheck) Key() string { return "php_project" }
+
+// Description provides a short explanation of what this check validates.
+func (PHPProjectCheck) Description() string {
+	return "Validates baseline conventions for Composer-based PHP projects"
+}
+
+type composerJSON struct {
+	Name        string          `json:"name"`
+	License     json.RawMessage `json:"license"`
+	Autoload    composerLoad    `json:"autoload"`
+	AutoloadDev composerLoad    `json:"autoload-dev"`
+}
+
+type composerLoad struct {
+	// PSR4 maps namespace prefixes to one directory or a list of them.
+	PSR4 map[string]json.RawMessage
+	// Keys records which autoload mechanisms are configured at all.
+	Keys map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes leniently: Composer tolerates an empty list for an
+// empty section, and a malformed mapping is Composer's error to report,
+// not a JSON syntax error.
+func (l *composerLoad) UnmarshalJSON(b []byte) error {
+	if json.Unmarshal(b, &l.Keys) == nil {
+		_ = json.Unmarshal(l.Keys["psr-4"], &l.PSR4)
+	}
+	return nil
+}
+
+// Run executes the PHP project validation.
+func (PHPProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "composer.json")
+	if err != nil {
+		// Not a PHP project, no-op.
+		return nil, nil
+	}
+	composerPath := filepath.Join(root, "composer.json")
+
+	var c composerJSON
+	if err := json.Unmarshal(b, &c); err != nil {
+		f := Finding{
+			Check:   "php_project",
+			Level:   LevelWarn,
+			Path:    composerPath,
+			Message: "composer.json is not valid JSON. Fix the syntax so Composer and this check can read it",
+		}
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			f.Line, f.Column = position(string(b), int(syn.Offset)-1)
+		}
+		return []Finding{f}, nil
+	}
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "php_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+
+	if !exists(fsys, "composer.lock") {
+		warn(composerPath, "composer.json found without composer.lock. Run composer install and commit composer.lock so installs are reproducible")
+	}
+	if c.Name == "" {
+		warn(composerPath, "composer.json has no name. Add a vendor/package name")
+	}
+	if !composerLicensed(c.License) {
+		warn(composerPath, "composer.json has no license. Add an SPDX license identifier, or \"proprietary\"")
+	}
+	if len(c.Autoload.Keys) == 0 {
+		warn(composerPath, "composer.json has no autoload section. Add PSR-4 autoloading for your source directory")
+	}
+	var missing []string
+	for _, l := range []composerLoad{c.Autoload, c.AutoloadDev} {
+		for _, dir := range psr4Dirs(l) {
+			if !isDir(fsys, cleanName(dir)) && !slices.Contains(missing, dir) {
+				missing = append(missing, dir)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		warn(composerPath, "PSR-4 autoload directories do not exist: "+strings.Join(missing, ", ")+". Create them or fix the autoload mapping")
+	}
+
+	if !isDir(fsys, "tests") {
+		warn(root, "No tests/ directory detected. Add tests/ to support CI validation")
+	}
+	if !exists(fsys, "phpunit.xml") && !exists(fsys, "phpunit.xml.dist") {
+		warn(root, "No PHPUnit configuration detected. Add phpunit.xml.dist so CI runs the test suite consistently")
+	}
+	return findings, nil
+}
+
+// composerLicensed reports whether license is a non-empty string or list.
+func composerLicensed(raw json.RawMessage) bool {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s) != ""
+	}
+	var list []string
+	return json.Unmarshal(raw, &list) == nil && len(list) > 0
+}
+
+// psr4Dirs returns every directory a PSR-4 mapping points at. An empty
+// path maps the package root and needs no directory.
+func psr4Dirs(l composerLoad) []string {
+	var dirs []string
+	for _, raw := range l.PSR4 {
+		var one string
+		var many []string
+		if json.Unmarshal(raw, &one) == nil {
+			many = []string{one}
+		} else if json.Unmarshal(raw, &many) != nil {
+			continue
+		}
+		for _, d := range many {
+			if d = strings.TrimSpace(d); d != "" && d != "./" && d != "." {
+				dirs = append(dirs, d)
+			}
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPHPProjectCheck_NoComposer_NoOp(t *testing.T) {
+	if fs := runCheck(t, PHPProjectCheck{}, map[string]string{"Gemfile": ""}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestPHPProjectCheck_ConventionalPackagePasses(t *testing.T) {
+	fs := runCheck(t, PHPProjectCheck{}, map[string]string{
+		"composer.json": `{
+  "name": "acme/demo",
+  "license": ["MIT", "GPL-2.0-or-later"],
+  "autoload": {"psr-4": {"Acme\\Demo\\": "src/", "Acme\\Extra\\": ["lib/", ""]}},
+  "autoload-dev": {"psr-4": {"Acme\\Demo\\Tests\\": "tests/"}}
+}`,
+		"composer.lock":      "{}",
+		"src/Demo.php":       "<?php\n",
+		"lib/Extra.php":      "<?php\n",
+		"tests/DemoTest.php": "<?php\n",
+		"phpunit.xml.dist":   "<phpunit/>\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestPHPProjectCheck_Rules(t *testing.T) {
+	const composer = `{"name": "acme/demo", "license": "MIT", "autoload": {"psr-4": {"Acme\\": "src/"}}}`
+	layout := func(composerJSON string, without ...string) map[string]string {
+		files := map[string]string{
+			"composer.json":      composerJSON,
+			"composer.lock":      "{}",
+			"src/Demo.php":       "<?php\n",
+			"tests/DemoTest.php": "<?php\n",
+			"phpunit.xml":        "<phpunit/>\n",
+		}
+		for _, name := range without {
+			delete(files, name)
+		}
+		return files
+	}
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"missing composer.lock": {
+			files: layout(composer, "composer.lock"),
+			path:  "composer.json",
+			msg:   "composer.json found without composer.lock. Run composer install and commit composer.lock so installs are reproducible",
+		},
+		"no name": {
+			files: layout(`{"license": "MIT", "autoload": {"psr-4": {"Acme\\": "src/"}}}`),
+			path:  "composer.json",
+			msg:   "composer.json has no name. Add a vendor/package name",
+		},
+		"empty license list": {
+			files: layout(`{"name": "acme/demo", "license": [], "autoload": {"psr-4": {"Acme\\": "src/"}}}`),
+			path:  "composer.json",
+			msg:   "composer.json has no license. Add an SPDX license identifier, or \"proprietary\"",
+		},
+		"empty autoload": {
+			files: layout(`{"name": "acme/demo", "license": "MIT", "autoload": []}`),
+			path:  "composer.json",
+			msg:   "composer.json has no autoload section. Add PSR-4 autoloading for your source directory",
+		},
+		"missing PSR-4 directories": {
+			files: layout(`{"name": "acme/demo", "license": "MIT", "autoload": {"psr-4": {"Acme\\": ["src/", "lib/"]}}, "autoload-dev": {"psr-4": {"Acme\\Tests\\": "spec/"}}}`),
+			path:  "composer.json",
+			msg:   "PSR-4 autoload directories do not exist: lib/, spec/. Create them or fix the autoload mapping",
+		},
+		"no tests directory": {
+			files: layout(composer, "tests/DemoTest.php"),
+			path:  ".",
+			msg:   "No tests/ directory detected. Add tests/ to support CI validation",
+		},
+		"no PHPUnit configuration": {
+			files: layout(composer, "phpunit.xml"),
+			path:  ".",
+			msg:   "No PHPUnit configuration detected. Add phpunit.xml.dist so CI runs the test suite consistently",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, PHPProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
+
+func TestPHPProjectCheck_InvalidJSON(t *testing.T) {
+	fs := runCheck(t, PHPProjectCheck{}, map[string]string{"composer.json": "{\n  \"name\": \"acme/demo\",\n}\n"})
+	if len(fs) != 1 || fs[0].Line != 3 || !strings.Contains(fs[0].Message, "not valid JSON") {
+		t.Fatalf("expected JSON syntax finding on line 3, got %+v", fs)
+	}
+}
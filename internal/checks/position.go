@@ -0,0 +1,24 @@
+package checks
+
+import "unicode/utf8"
+
+// position converts a byte offset in content to a 1-based line and column,
+// counting columns in code points to match Finding.
+func position(content string, off int) (line, col int) {
+	off = min(max(off, 0), len(content))
+	line, start := 1, 0
+	for i := 0; i < off; i++ {
+		if content[i] == '\n' {
+			line++
+			start = i + 1
+		}
+	}
+	return line, utf8.RuneCountInString(content[start:off]) + 1
+}
+
+// withSpan returns f located at the byte range [start, end) of content.
+func withSpan(f Finding, content string, start, end int) Finding {
+	f.Line, f.Column = position(content, start)
+	f.EndLine, f.EndColumn = position(content, end)
+	return f
+}
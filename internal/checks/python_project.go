@@ -2,11 +2,28 @@ package checks
 
 import (
 	"context"
-	"os"
+	"errors"
+	"io/fs"
 	"path/filepath"
+	"slices"
+
+	"github.com/hittegit/yardstick/internal/toml"
 )
 
 // PythonProjectCheck validates baseline conventions for Python projects.
+//
+// Behavior
+//   - No-op when neither pyproject.toml nor requirements.txt is present.
+//   - Warns when requirements.txt is the only manifest, and when there is
+//     no test layout or configuration.
+//   - Parses pyproject.toml and validates [build-system] (requires and
+//     build-backend) and PEP 621 [project] metadata: name, version (or
+//     dynamic), requires-python, license, and a readme that exists.
+//     Projects that keep metadata in [tool.poetry] skip the [project]
+//     checks, and applications that opt out of packaging skip
+//     [build-system].
+//   - Detects the packaging tool (poetry, pdm, uv, hatch, setuptools) and
+//     warns when the lockfile of a tool that writes one is missing.
 type PythonProjectCheck struct{}
 
 func (PythonProjectCheck) Key() string { return "python_project" }
@@ -15,12 +32,25 @@ func (PythonProjectCheck) Description() string {
 	return "Validates baseline conventions for Python projects"
 }
 
-func (PythonProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	pyprojectPath := filepath.Join(root, "pyproject.toml")
-	requirementsPath := filepath.Join(root, "requirements.txt")
+// pythonTools lists packaging tools in detection order: a [tool.<name>]
+// table or a matching build backend identifies the tool. Workflow tools
+// come first so that, for example, uv with a hatchling backend is uv.
+var pythonTools = []struct {
+	name     string
+	backends []string
+	lockfile string // empty when the tool does not write one
+}{
+	{"poetry", []string{"poetry.core.masonry.api"}, "poetry.lock"},
+	{"pdm", []string{"pdm.backend", "pdm.pep517.api"}, "pdm.lock"},
+	{"uv", []string{"uv_build"}, "uv.lock"},
+	{"hatch", []string{"hatchling.build"}, ""},
+	{"setuptools", []string{"setuptools.build_meta", "setuptools.build_meta:__legacy__"}, ""},
+}
 
-	hasPyproject := fileExists(pyprojectPath)
-	hasRequirements := fileExists(requirementsPath)
+func (PythonProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	hasPyproject := exists(fsys, "pyproject.toml")
+	hasRequirements := exists(fsys, "requirements.txt")
 	// Not a Python project we recognize, no-op.
 	if !hasPyproject && !hasRequirements {
 		return nil, nil
@@ -33,12 +63,16 @@ func (PythonProjectCheck) Run(ctx context.Context, root string, opts Options) ([
 		findings = append(findings, Finding{
 			Check:   "python_project",
 			Level:   LevelWarn,
-			Path:    requirementsPath,
+			Path:    filepath.Join(root, "requirements.txt"),
 			Message: "requirements.txt found without pyproject.toml. Add pyproject.toml for modern tooling and metadata interoperability",
 		})
 	}
 
-	if !hasPythonTestSignal(root) {
+	if hasPyproject {
+		findings = append(findings, checkPyproject(fsys, root)...)
+	}
+
+	if !hasPythonTestSignal(fsys) {
 		findings = append(findings, Finding{
 			Check:   "python_project",
 			Level:   LevelWarn,
@@ -50,20 +84,115 @@ func (PythonProjectCheck) Run(ctx context.Context, root string, opts Options) ([
 	return findings, nil
 }
 
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
+// checkPyproject validates build-system, PEP 621 metadata, and the
+// lockfile of the detected packaging tool.
+func checkPyproject(fsys fs.FS, root string) []Finding {
+	pyproject := filepath.Join(root, "pyproject.toml")
+	var findings []Finding
+	warn := func(line int, msg string) {
+		findings = append(findings, Finding{Check: "python_project", Level: LevelWarn, Path: pyproject, Message: msg, Line: line})
+	}
+
+	b, err := fs.ReadFile(fsys, "pyproject.toml")
+	if err != nil {
+		return nil
+	}
+	doc, err := toml.Parse(b)
+	if err != nil {
+		line := 0
+		var te *toml.Error
+		if errors.As(err, &te) {
+			line = te.Line
+		}
+		warn(line, "pyproject.toml is not valid TOML ("+err.Error()+"). Fix the syntax so build tools and this check can read it")
+		return findings
+	}
+
+	backend, _ := doc.Root.String("build-system", "build-backend")
+	for _, t := range pythonTools {
+		if _, ok := doc.Root.Table("tool", t.name); ok || slices.Contains(t.backends, backend) {
+			if t.lockfile != "" && !exists(fsys, t.lockfile) {
+				warn(0, "pyproject.toml is managed by "+t.name+" but "+t.lockfile+" is missing. Run "+t.name+" lock and commit "+t.lockfile+" so dependency versions are reproducible")
+			}
+			break
+		}
+	}
 
-func hasPythonTestSignal(root string) bool {
-	testsDir := filepath.Join(root, "tests")
-	pytestINI := filepath.Join(root, "pytest.ini")
-	toxINI := filepath.Join(root, "tox.ini")
-	noxfile := filepath.Join(root, "noxfile.py")
-	setupCFG := filepath.Join(root, "setup.cfg")
+	// Poetry and uv applications may opt out of packaging, in which case
+	// there is nothing to build.
+	packaged := true
+	if v, ok := doc.Root.Get("tool", "poetry", "package-mode"); ok && v == false {
+		packaged = false
+	}
+	if v, ok := doc.Root.Get("tool", "uv", "package"); ok && v == false {
+		packaged = false
+	}
+	if buildSystem, ok := doc.Root.Table("build-system"); !ok {
+		if packaged {
+			warn(0, "pyproject.toml has no [build-system] table, so installers fall back to legacy setuptools. Add [build-system] with requires and build-backend")
+		}
+	} else {
+		line := doc.Line("build-system")
+		if requires, ok := buildSystem.Array("requires"); !ok || len(requires) == 0 {
+			warn(line, "[build-system] does not list requires. Add the build backend package, for example requires = [\"hatchling\"]")
+		}
+		if backend == "" {
+			warn(line, "[build-system] does not set build-backend, so installers fall back to legacy setuptools. Set build-backend explicitly")
+		}
+	}
+
+	project, ok := doc.Root.Table("project")
+	if !ok {
+		if _, poetry := doc.Root.Table("tool", "poetry"); !poetry {
+			warn(0, "pyproject.toml has no [project] table. Add PEP 621 metadata (name, version, requires-python, license, readme)")
+		}
+		return findings
+	}
+	line := doc.Line("project")
+	var dynamic []string
+	if d, ok := project.Array("dynamic"); ok {
+		for _, v := range d {
+			if s, ok := v.(string); ok {
+				dynamic = append(dynamic, s)
+			}
+		}
+	}
+	missing := func(key string) bool {
+		_, set := project[key]
+		return !set && !slices.Contains(dynamic, key)
+	}
+
+	if name, _ := project.String("name"); name == "" {
+		warn(line, "[project] does not set name. Add the distribution name; it cannot be dynamic")
+	}
+	if missing("version") {
+		warn(line, "[project] does not set version. Add version, or list it in dynamic when a build plugin provides it")
+	}
+	if missing("requires-python") {
+		warn(line, "[project] does not set requires-python. Declare the supported Python versions so installers reject incompatible interpreters")
+	}
+	if missing("license") {
+		warn(line, "[project] does not set license. Add an SPDX license expression, for example license = \"MIT\"")
+	} else if file, ok := project.String("license", "file"); ok && !exists(fsys, cleanName(file)) {
+		warn(doc.Line("project", "license"), "[project] license file "+file+" does not exist. Fix the path or use an SPDX license expression")
+	}
+	if missing("readme") {
+		warn(line, "[project] does not set readme. Point readme at README.md so the package index shows a description")
+	} else {
+		file, ok := project.String("readme")
+		if !ok {
+			file, ok = project.String("readme", "file")
+		}
+		if ok && !exists(fsys, cleanName(file)) {
+			warn(doc.Line("project", "readme"), "[project] readme "+file+" does not exist. Fix the path or add the file")
+		}
+	}
+	return findings
+}
 
-	if st, err := os.Stat(testsDir); err == nil && st.IsDir() {
+func hasPythonTestSignal(fsys fs.FS) bool {
+	if isDir(fsys, "tests") {
 		return true
 	}
-	return fileExists(pytestINI) || fileExists(toxINI) || fileExists(noxfile) || fileExists(setupCFG)
+	return exists(fsys, "pytest.ini") || exists(fsys, "tox.ini") || exists(fsys, "noxfile.py") || exists(fsys, "setup.cfg")
 }
@@ -4,9 +4,24 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
+// validPyproject is a complete setuptools pyproject.toml. Tests using it
+// also write README.md.
+const validPyproject = `[build-system]
+requires = ["setuptools>=68"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = "demo"
+version = "0.1.0"
+requires-python = ">=3.10"
+license = "MIT"
+readme = "README.md"
+`
+
 func TestPythonProjectCheck_NoPythonSignals_NoOp(t *testing.T) {
 	dir := t.TempDir()
 	fs, err := (PythonProjectCheck{}).Run(context.Background(), dir, Options{})
@@ -20,9 +35,12 @@ func TestPythonProjectCheck_NoPythonSignals_NoOp(t *testing.T) {
 
 func TestPythonProjectCheck_PyprojectWithTests_NoFindings(t *testing.T) {
 	dir := t.TempDir()
-	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname='demo'\n"), 0o644); err != nil {
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(validPyproject), 0o644); err != nil {
 		t.Fatalf("write pyproject.toml: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# demo\n"), 0o644); err != nil {
+		t.Fatalf("write README.md: %v", err)
+	}
 	if err := os.Mkdir(filepath.Join(dir, "tests"), 0o750); err != nil {
 		t.Fatalf("mkdir tests: %v", err)
 	}
@@ -58,9 +76,12 @@ func TestPythonProjectCheck_RequirementsOnlyWarns(t *testing.T) {
 
 func TestPythonProjectCheck_PyprojectMissingTestSignal_Warns(t *testing.T) {
 	dir := t.TempDir()
-	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname='demo'\n"), 0o644); err != nil {
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(validPyproject), 0o644); err != nil {
 		t.Fatalf("write pyproject.toml: %v", err)
 	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# demo\n"), 0o644); err != nil {
+		t.Fatalf("write README.md: %v", err)
+	}
 
 	fs, err := (PythonProjectCheck{}).Run(context.Background(), dir, Options{})
 	if err != nil {
@@ -70,3 +91,119 @@ func TestPythonProjectCheck_PyprojectMissingTestSignal_Warns(t *testing.T) {
 		t.Fatalf("expected 1 finding, got %d (%+v)", len(fs), fs)
 	}
 }
+
+func TestPythonProjectCheck_PyprojectToolsPass(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"poetry legacy metadata": {
+			"pyproject.toml": "[tool.poetry]\nname = \"demo\"\nversion = \"1.0.0\"\n\n[build-system]\nrequires = [\"poetry-core\"]\nbuild-backend = \"poetry.core.masonry.api\"\n",
+			"poetry.lock":    "",
+		},
+		"uv application": {
+			"pyproject.toml": `[project]
+name = "app"
+version = "0.1.0"
+requires-python = ">=3.12"
+license = { file = "LICENSE" }
+readme = { file = "docs/README.md", content-type = "text/markdown" }
+
+[tool.uv]
+package = false
+`,
+			"uv.lock":        "",
+			"LICENSE":        "MIT",
+			"docs/README.md": "# app",
+		},
+		"hatch with dynamic version": {
+			"pyproject.toml": `[build-system]
+requires = ["hatchling", "hatch-vcs"]
+build-backend = "hatchling.build"
+
+[project]
+name = "lib"
+dynamic = ["version"]
+requires-python = ">=3.9"
+license = "Apache-2.0"
+readme = "README.md"
+`,
+			"README.md": "# lib",
+		},
+	} {
+		files["tests/test_x.py"] = ""
+		if fs := runCheck(t, PythonProjectCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected no findings, got:\n%s", name, findingMessages(fs))
+		}
+	}
+}
+
+func TestPythonProjectCheck_PyprojectMetadataGaps(t *testing.T) {
+	fs := runCheck(t, PythonProjectCheck{}, map[string]string{
+		"pyproject.toml": `[build-system]
+requires = []
+
+[project]
+version = "1.0"
+license = { file = "COPYING" }
+readme = "README.rst"
+`,
+		"tests/test_x.py": "",
+	})
+	got := findingMessages(fs)
+	for _, want := range []string{
+		"[build-system] does not list requires",
+		"[build-system] does not set build-backend",
+		"[project] does not set name",
+		"[project] does not set requires-python",
+		"[project] license file COPYING does not exist",
+		"[project] readme README.rst does not exist",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if len(fs) != 6 {
+		t.Fatalf("expected 6 findings, got:\n%s", got)
+	}
+	for _, f := range fs {
+		if f.Line == 0 {
+			t.Errorf("expected a line for %q", f.Message)
+		}
+	}
+}
+
+func TestPythonProjectCheck_PyprojectMissingTables(t *testing.T) {
+	fs := runCheck(t, PythonProjectCheck{}, map[string]string{
+		"pyproject.toml":  "[tool.ruff]\nline-length = 100\n",
+		"tests/test_x.py": "",
+	})
+	got := findingMessages(fs)
+	if len(fs) != 2 || !strings.Contains(got, "no [build-system] table") || !strings.Contains(got, "no [project] table") {
+		t.Fatalf("unexpected findings:\n%s", got)
+	}
+}
+
+func TestPythonProjectCheck_MissingToolLockfile(t *testing.T) {
+	for tool, pyproject := range map[string]string{
+		"poetry.lock": "[tool.poetry]\nname = \"demo\"\npackage-mode = false\n",
+		"pdm.lock":    validPyproject + "\n[tool.pdm]\ndistribution = true\n",
+		"uv.lock":     strings.Replace(validPyproject, `"setuptools.build_meta"`, `"uv_build"`, 1),
+	} {
+		fs := runCheck(t, PythonProjectCheck{}, map[string]string{
+			"pyproject.toml":  pyproject,
+			"README.md":       "# demo",
+			"tests/test_x.py": "",
+		})
+		if len(fs) != 1 || !strings.Contains(fs[0].Message, tool+" is missing") {
+			t.Errorf("%s: unexpected findings:\n%s", tool, findingMessages(fs))
+		}
+	}
+}
+
+func TestPythonProjectCheck_InvalidPyproject(t *testing.T) {
+	fs := runCheck(t, PythonProjectCheck{}, map[string]string{
+		"pyproject.toml":  "[project]\nname = \"demo\nversion = \"1\"\n",
+		"tests/test_x.py": "",
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "pyproject.toml is not valid TOML") || fs[0].Line != 2 {
+		t.Fatalf("unexpected findings: %+v", fs)
+	}
+}
@@ -2,7 +2,7 @@ package checks
 
 import (
 	"context"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
 )
@@ -22,6 +22,16 @@ func (ReadmeCheck) Description() string {
 	return "Ensures README.md exists and includes required sections"
 }
 
+// defaultReadmeSections are the headings required when none are configured.
+var defaultReadmeSections = []string{"## Overview", "## Installation", "## Usage", "## CI", "## License"}
+
+// Schema declares the settings accepted in the config file.
+func (ReadmeCheck) Schema() []Setting {
+	return []Setting{
+		{Name: "sections", Kind: SettingStringList, Description: "headings README.md must contain, matched as written (e.g. \"## Runbook\")"},
+	}
+}
+
 // Run executes the README validation logic.
 //
 // Behavior:
@@ -30,22 +40,24 @@ func (ReadmeCheck) Description() string {
 //   - Missing sections are reported as warnings.
 func (ReadmeCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
 	path := filepath.Join(root, "README.md")
+	required := opts.Settings.StringList("sections", defaultReadmeSections)
+	if len(required) == 0 {
+		required = defaultReadmeSections
+	}
 
-	// #nosec G304 -- root/path are intentionally user-selected scan targets.
-	b, err := os.ReadFile(path)
+	b, err := fs.ReadFile(opts.fsys(root), "README.md")
 	if err != nil {
 		// Read-only policy: never write, provide guidance only.
 		return []Finding{{
 			Check:   "readme",
 			Level:   LevelWarn,
 			Path:    path,
-			Message: "README.md missing. Create README.md with sections: Overview, Installation, Usage, CI, License",
+			Message: "README.md missing. Create README.md with sections: " + sectionNames(required),
 		}}, nil
 	}
 
 	// Check for required section headers.
 	content := string(b)
-	required := []string{"## Overview", "## Installation", "## Usage", "## CI", "## License"}
 	var findings []Finding
 
 	for _, section := range required {
@@ -61,3 +73,12 @@ func (ReadmeCheck) Run(ctx context.Context, root string, opts Options) ([]Findin
 
 	return findings, nil
 }
+
+// sectionNames renders headings without their markdown prefix for messages.
+func sectionNames(sections []string) string {
+	names := make([]string, 0, len(sections))
+	for _, s := range sections {
+		names = append(names, strings.TrimSpace(strings.TrimLeft(s, "#")))
+	}
+	return strings.Join(names, ", ")
+}
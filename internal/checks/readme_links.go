@@ -2,7 +2,9 @@ package checks
 
 import (
 	"context"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -22,19 +24,28 @@ var markdownLinkPattern = regexp.MustCompile(`\[[^\]]+\]\(([^)]+)\)`)
 var markdownHeadingPattern = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
 
 func (ReadmeLinksCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
 	readmePath := filepath.Join(root, "README.md")
-	// #nosec G304 -- root/path are intentionally user-selected scan targets.
-	b, err := os.ReadFile(readmePath)
+	b, err := fs.ReadFile(fsys, "README.md")
 	if err != nil {
 		return nil, nil
 	}
 
+	// Links above the root resolve only when the tree is the live
+	// directory at root.
+	onDisk := opts.onDisk(root)
+
 	content := string(b)
 	anchors := readmeAnchors(content)
 	matches := markdownLinkPattern.FindAllStringSubmatchIndex(content, -1)
 	var findings []Finding
 
 	for _, m := range matches {
+		// Large READMEs can hold thousands of links; stop once the run's
+		// per-check timeout has passed.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if len(m) < 4 {
 			continue
 		}
@@ -58,12 +69,12 @@ func (ReadmeLinksCheck) Run(ctx context.Context, root string, opts Options) ([]F
 
 		if strings.HasPrefix(target, "#") {
 			if _, ok := anchors[target[1:]]; !ok {
-				findings = append(findings, Finding{
+				findings = append(findings, withSpan(Finding{
 					Check:   "readme_links",
 					Level:   LevelWarn,
 					Path:    readmePath,
 					Message: "README link target not found: " + target,
-				})
+				}, content, m[0], m[1]))
 			}
 			continue
 		}
@@ -72,29 +83,50 @@ func (ReadmeLinksCheck) Run(ctx context.Context, root string, opts Options) ([]F
 		if pathPart == "" {
 			continue
 		}
-		fullPath := filepath.Join(root, filepath.FromSlash(pathPart))
-		info, statErr := os.Stat(fullPath) // #nosec G703 -- path scoped to user-provided repository root via filepath.Join
+		name, inside := linkName(pathPart)
+		linkFS := fsys
+		var info fs.FileInfo
+		var statErr error
+		switch {
+		case inside:
+			info, statErr = fs.Stat(fsys, name)
+		case onDisk:
+			// Targets above the repository root are outside the scanned
+			// tree, but a directory scan can still resolve them on disk.
+			fullPath := filepath.Join(root, filepath.FromSlash(pathPart))
+			info, statErr = os.Stat(fullPath) // #nosec G703 -- path scoped to user-provided repository root via filepath.Join
+			linkFS, name = os.DirFS(filepath.Dir(fullPath)), filepath.Base(fullPath)
+		default:
+			// Archives and in-memory trees have nothing above their root.
+			findings = append(findings, withSpan(Finding{
+				Check:   "readme_links",
+				Level:   LevelWarn,
+				Path:    readmePath,
+				Message: "README link cannot be checked in this tree: " + target,
+			}, content, m[0], m[1]))
+			continue
+		}
 		if statErr != nil {
-			findings = append(findings, Finding{
+			findings = append(findings, withSpan(Finding{
 				Check:   "readme_links",
 				Level:   LevelWarn,
 				Path:    readmePath,
 				Message: "README link file not found: " + target,
-			})
+			}, content, m[0], m[1]))
 			continue
 		}
-		if frag != "" && !info.IsDir() && looksLikeMarkdown(fullPath) {
-			ok, anchorErr := markdownFileHasAnchor(fullPath, frag)
+		if frag != "" && !info.IsDir() && looksLikeMarkdown(pathPart) {
+			ok, anchorErr := markdownFileHasAnchor(linkFS, name, frag)
 			if anchorErr != nil {
 				return nil, anchorErr
 			}
 			if !ok {
-				findings = append(findings, Finding{
+				findings = append(findings, withSpan(Finding{
 					Check:   "readme_links",
 					Level:   LevelWarn,
 					Path:    readmePath,
 					Message: "README link anchor not found in " + pathPart + ": #" + frag,
-				})
+				}, content, m[0], m[1]))
 			}
 		}
 	}
@@ -115,9 +147,15 @@ func looksLikeMarkdown(path string) bool {
 	return ext == ".md" || ext == ".markdown"
 }
 
-func markdownFileHasAnchor(path, anchor string) (bool, error) {
-	// #nosec G304 G703 -- path is derived from the selected repository root.
-	b, err := os.ReadFile(path)
+// linkName maps a README link target to an io/fs name. ok is false when the
+// target points above the repository root, which the fs.FS cannot reach.
+func linkName(target string) (name string, ok bool) {
+	name = path.Join(".", target)
+	return name, fs.ValidPath(name)
+}
+
+func markdownFileHasAnchor(fsys fs.FS, name, anchor string) (bool, error) {
+	b, err := fs.ReadFile(fsys, name)
 	if err != nil {
 		return false, err
 	}
@@ -5,6 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
+
+	"github.com/hittegit/yardstick/internal/repo"
 )
 
 func TestReadmeLinksCheck_NoReadmeNoop(t *testing.T) {
@@ -33,6 +36,25 @@ func TestReadmeLinksCheck_BrokenSelfAnchorWarns(t *testing.T) {
 	}
 }
 
+func TestReadmeLinksCheck_FindingsCarryLinkSpan(t *testing.T) {
+	dir := t.TempDir()
+	readme := "# Title\n\nIntro line.\nSee the [gü](nope.md) file.\n"
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0o644); err != nil {
+		t.Fatalf("write README: %v", err)
+	}
+	fs, err := (ReadmeLinksCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 {
+		t.Fatalf("unexpected findings: %+v", fs)
+	}
+	f := fs[0]
+	if f.Line != 4 || f.Column != 9 || f.EndLine != 4 || f.EndColumn != 22 {
+		t.Fatalf("unexpected span %d:%d-%d:%d", f.Line, f.Column, f.EndLine, f.EndColumn)
+	}
+}
+
 func TestReadmeLinksCheck_MissingLocalFileWarns(t *testing.T) {
 	dir := t.TempDir()
 	readme := "# Title\n\nSee [guide](docs/guide.md).\n"
@@ -77,3 +99,83 @@ func TestReadmeLinksCheck_ExistingLinksPass(t *testing.T) {
 		t.Fatalf("expected no findings, got %+v", fs)
 	}
 }
+
+func TestReadmeLinksCheck_ScansGivenFS(t *testing.T) {
+	readme := "" +
+		"# Project\n\n" +
+		"- [Guide section](docs/guide.md#missing)\n" +
+		"- [Sibling](../other/README.md)\n"
+	tree := fstest.MapFS{
+		"README.md":     {Data: []byte(readme)},
+		"docs/guide.md": {Data: []byte("# Guide\n")},
+	}
+
+	// root only names the tree in findings; nothing is read from disk.
+	root := filepath.Join(t.TempDir(), "project.tar.gz")
+	fs, err := (ReadmeLinksCheck{}).Run(context.Background(), root, Options{FS: tree})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 2 || fs[0].Path != filepath.Join(root, "README.md") ||
+		fs[0].Message != "README link anchor not found in docs/guide.md: #missing" ||
+		fs[1].Message != "README link cannot be checked in this tree: ../other/README.md" {
+		t.Fatalf("unexpected findings: %+v", fs)
+	}
+}
+
+func TestReadmeLinksCheck_DirectoryScanResolvesLinksAboveRoot(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "project")
+	readme := "" +
+		"# Project\n\n" +
+		"- [Sibling](../sibling.md#intro)\n" +
+		"- [Missing](../missing.md)\n"
+	writeFiles(t, parent, map[string]string{
+		"sibling.md":        "# Intro\n",
+		"project/README.md": readme,
+	})
+
+	fs, err := (ReadmeLinksCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Message != "README link file not found: ../missing.md" {
+		t.Fatalf("unexpected findings: %+v", fs)
+	}
+}
+
+func TestReadmeLinksCheck_IndexedTreesAboveRoot(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "project")
+	writeFiles(t, parent, map[string]string{
+		"sibling.md":        "# Intro\n",
+		"project/README.md": "# Project\n\n- [Sibling](../sibling.md#intro)\n",
+	})
+
+	// An index of the directory itself may read above the root.
+	idx, err := repo.Build(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	fs, err := (ReadmeLinksCheck{}).Run(context.Background(), dir, Options{FS: idx})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("expected the sibling to resolve on disk, got %+v", fs)
+	}
+
+	// A tree from another source, such as a -rev commit, is named by the
+	// same root but must not consult the working tree around it.
+	idx, err = repo.FromFS(context.Background(), os.DirFS(dir), dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	fs, err = (ReadmeLinksCheck{}).Run(context.Background(), dir, Options{FS: idx})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Message != "README link cannot be checked in this tree: ../sibling.md#intro" {
+		t.Fatalf("unexpected findings: %+v", fs)
+	}
+}
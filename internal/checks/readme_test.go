@@ -1,56 +1,85 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
-    "testing"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
 )
 
 func TestReadmeCheck_Missing_ReadOnly(t *testing.T) {
-    dir := t.TempDir()
-    path := filepath.Join(dir, "README.md")
-    fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{AutoFix: false})
-    if err != nil {
-        t.Fatalf("run: %v", err)
-    }
-    if len(fs) != 1 || fs[0].Level != LevelWarn {
-        t.Fatalf("expected one warn finding, got %+v", fs)
-    }
-    if _, err := os.Stat(path); !os.IsNotExist(err) {
-        t.Fatalf("README should not be created")
-    }
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{AutoFix: false})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Level != LevelWarn {
+		t.Fatalf("expected one warn finding, got %+v", fs)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("README should not be created")
+	}
 }
 
 func TestReadmeCheck_Missing_NoWriteEvenWithFix(t *testing.T) {
-    dir := t.TempDir()
-    path := filepath.Join(dir, "README.md")
-    fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{AutoFix: true})
-    if err != nil {
-        t.Fatalf("run: %v", err)
-    }
-    if len(fs) != 1 || fs[0].Fixed {
-        t.Fatalf("expected one warn finding with Fixed=false, got %+v", fs)
-    }
-    if _, err := os.Stat(path); !os.IsNotExist(err) {
-        t.Fatalf("README should not be created when AutoFix is true (read-only policy)")
-    }
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{AutoFix: true})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Fixed {
+		t.Fatalf("expected one warn finding with Fixed=false, got %+v", fs)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("README should not be created when AutoFix is true (read-only policy)")
+	}
 }
 
 func TestReadmeCheck_MissingSections(t *testing.T) {
-    dir := t.TempDir()
-    path := filepath.Join(dir, "README.md")
-    // Provide only two sections so we get warnings for the rest
-    content := "# Title\n\n## Overview\ntext\n\n## Usage\ntext\n"
-    if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
-        t.Fatalf("write README: %v", err)
-    }
-    fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{})
-    if err != nil {
-        t.Fatalf("run: %v", err)
-    }
-    // Required: Overview, Installation, Usage, CI, License -> we provided 2
-    if len(fs) != 3 {
-        t.Fatalf("expected 3 warnings for missing sections, got %d", len(fs))
-    }
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	// Provide only two sections so we get warnings for the rest
+	content := "# Title\n\n## Overview\ntext\n\n## Usage\ntext\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write README: %v", err)
+	}
+	fs, err := (ReadmeCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// Required: Overview, Installation, Usage, CI, License -> we provided 2
+	if len(fs) != 3 {
+		t.Fatalf("expected 3 warnings for missing sections, got %d", len(fs))
+	}
+}
+
+func TestReadmeCheck_ConfiguredSections(t *testing.T) {
+	dir := t.TempDir()
+	content := "# Service\n\n## Overview\ntext\n\n## Runbook\nsteps\n"
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write README: %v", err)
+	}
+	opts := Options{Settings: Settings{"sections": []string{"## Overview", "## Runbook", "## On-call"}}}
+	fs, err := (ReadmeCheck{}).Run(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Message != "Missing section: ## On-call" {
+		t.Fatalf("expected only the On-call section to be missing, got %+v", fs)
+	}
+}
+
+func TestReadmeCheck_EmptySectionsUseDefaults(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Settings: Settings{"sections": []string{}}}
+	fs, err := (ReadmeCheck{}).Run(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	want := "README.md missing. Create README.md with sections: Overview, Installation, Usage, CI, License"
+	if len(fs) != 1 || fs[0].Message != want {
+		t.Fatalf("expected default sections in guidance, got %+v", fs)
+	}
 }
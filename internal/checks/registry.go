@@ -18,12 +18,24 @@ func All() []Check {
 	return []Check{
 		ManifestCheck{},            // Detect project ecosystem by scanning for common manifests
 		JavaScriptFrameworkCheck{}, // Validate baseline conventions for JavaScript framework projects
+		NodePackageManagerCheck{},  // Validate Node lockfiles and package manager consistency
+		TypeScriptCheck{},          // Validate tsconfig.json strictness, extends, and include paths
 		PythonProjectCheck{},       // Validate baseline conventions for Python projects
+		GoModuleCheck{},            // Validate go.mod, go.sum, and vendoring hygiene
+		RustProjectCheck{},         // Validate baseline conventions for Rust projects
+		RubyProjectCheck{},         // Validate baseline conventions for Ruby projects
+		PHPProjectCheck{},          // Validate baseline conventions for PHP projects
+		JVMProjectCheck{},          // Validate Maven/Gradle wrappers and test layout
+		DotnetProjectCheck{},       // Validate .NET lockfiles, SDK pinning, and tests
+		SwiftProjectCheck{},        // Validate Swift package lockfile, tools version, and tests
+		ElixirProjectCheck{},       // Validate Mix lockfile, version pinning, and tests
+		DartProjectCheck{},         // Validate Dart/Flutter lockfile, SDK constraint, and tests
 		StaticSiteCheck{},          // Validate minimal structure for static sites (e.g., Jekyll)
 		ReadmeCheck{},              // Ensures README.md exists and has required sections
 		ReadmeLinksCheck{},         // Verifies local README links resolve
 		LicenseCheck{},             // Ensures LICENSE file is present
 		GitIgnoreCheck{},           // Ensures .gitignore covers common entries
+		TrackedFilesCheck{},        // Flags committed files that .gitignore ignores
 		ChangelogCheck{},           // Ensures CHANGELOG.md exists
 		CodeownersCheck{},          // Ensures CODEOWNERS exists in standard GitHub locations
 		SecurityPolicyCheck{},      // Ensures SECURITY.md exists in standard GitHub locations
@@ -0,0 +1,136 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RubyProjectCheck validates baseline conventions for Ruby projects.
+//
+// Behavior
+//   - No-op when neither a Gemfile nor a root *.gemspec exists.
+//   - Warns when a Gemfile has no Gemfile.lock next to it.
+//   - Warns when the Ruby version is not pinned by .ruby-version,
+//     .tool-versions, or a ruby directive in the Gemfile.
+//   - Warns when there is no spec/ or test/ directory.
+//   - Warns about gemspecs without summary, license, homepage, or
+//     required_ruby_version, and about a Gemfile gemspec directive without
+//     a gemspec to load.
+type RubyProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (RubyProjectCheck) Key() string { return "ruby_project" }
+
+// Description provides a short explanation of what this check validates.
+func (RubyProjectCheck) Description() string {
+	return "Validates baseline conventions for Ruby projects and gems"
+}
+
+// gemspecFields are the attributes a published gem should set. Either
+// license or licenses satisfies the license entry.
+var gemspecFields = []struct {
+	attrs []string
+	label string
+}{
+	{[]string{"summary"}, "summary"},
+	{[]string{"license", "licenses"}, "license"},
+	{[]string{"homepage"}, "homepage"},
+	{[]string{"required_ruby_version"}, "required_ruby_version"},
+}
+
+var (
+	// gemfileRuby matches a ruby version directive, e.g. ruby "3.3.0" or
+	// ruby file: ".ruby-version".
+	gemfileRuby = regexp.MustCompile(`(?m)^\s*ruby[\s(]`)
+	// gemfileGemspec matches the gemspec directive.
+	gemfileGemspec = regexp.MustCompile(`(?m)^\s*gemspec\b`)
+	// gemspecAssign matches attribute assignments such as spec.summary = and
+	// s.licenses = inside a Gem::Specification block.
+	gemspecAssign = regexp.MustCompile(`(?m)^\s*\w+\.(\w+)\s*=`)
+)
+
+// Run executes the Ruby project validation.
+func (RubyProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	gemspecs, err := fs.Glob(fsys, "*.gemspec")
+	if err != nil {
+		return nil, err
+	}
+	gemfile, gemfileErr := fs.ReadFile(fsys, "Gemfile")
+	hasGemfile := gemfileErr == nil
+	// Not a Ruby project we recognize, no-op.
+	if !hasGemfile && len(gemspecs) == 0 {
+		return nil, nil
+	}
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "ruby_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+	gemfilePath := filepath.Join(root, "Gemfile")
+
+	if hasGemfile && !exists(fsys, "Gemfile.lock") {
+		warn(gemfilePath, "Gemfile found without Gemfile.lock. Run bundle install and commit Gemfile.lock so installs are reproducible")
+	}
+	if !rubyVersionPinned(fsys, gemfile) {
+		warn(root, "Ruby version is not pinned. Add .ruby-version or a ruby directive in the Gemfile so local and CI runs use the same interpreter")
+	}
+	if !isDir(fsys, "spec") && !isDir(fsys, "test") {
+		warn(root, "No Ruby test layout detected. Add spec/ (RSpec) or test/ (Minitest) to support CI validation")
+	}
+	if hasGemfile && len(gemspecs) == 0 && gemfileGemspec.Match(gemfile) {
+		warn(gemfilePath, "Gemfile calls gemspec but no *.gemspec exists at the root. Add the gemspec or remove the directive")
+	}
+
+	for _, name := range gemspecs {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			continue
+		}
+		set := map[string]bool{}
+		for _, m := range gemspecAssign.FindAllSubmatch(b, -1) {
+			set[string(m[1])] = true
+		}
+		var missing []string
+		for _, f := range gemspecFields {
+			if !anySet(set, f.attrs) {
+				missing = append(missing, f.label)
+			}
+		}
+		if len(missing) > 0 {
+			warn(filepath.Join(root, name), name+" is missing gem metadata: "+strings.Join(missing, ", ")+". Set them so RubyGems and users can evaluate the gem")
+		}
+	}
+	return findings, nil
+}
+
+// rubyVersionPinned reports whether the project pins its Ruby version.
+func rubyVersionPinned(fsys fs.FS, gemfile []byte) bool {
+	return exists(fsys, ".ruby-version") || gemfileRuby.Match(gemfile) || toolVersionPinned(fsys, "ruby")
+}
+
+// toolVersionPinned reports whether .tool-versions (asdf, mise) pins tool.
+func toolVersionPinned(fsys fs.FS, tool string) bool {
+	b, err := fs.ReadFile(fsys, ".tool-versions")
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if f := strings.Fields(line); len(f) >= 2 && f[0] == tool {
+			return true
+		}
+	}
+	return false
+}
+
+func anySet(set map[string]bool, attrs []string) bool {
+	for _, a := range attrs {
+		if set[a] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package checks
+
+import "testing"
+
+func TestRubyProjectCheck_NoRubySignals_NoOp(t *testing.T) {
+	if fs := runCheck(t, RubyProjectCheck{}, map[string]string{"package.json": "{}"}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestRubyProjectCheck_ConventionalAppPasses(t *testing.T) {
+	fs := runCheck(t, RubyProjectCheck{}, map[string]string{
+		"Gemfile":          "source \"https://rubygems.org\"\nruby \"3.3.0\"\ngem \"rails\"\n",
+		"Gemfile.lock":     "GEM\n",
+		"spec/app_spec.rb": "",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestRubyProjectCheck_Rules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"missing Gemfile.lock": {
+			files: map[string]string{"Gemfile": "ruby \"3.3.0\"\n", "spec/app_spec.rb": ""},
+			path:  "Gemfile",
+			msg:   "Gemfile found without Gemfile.lock. Run bundle install and commit Gemfile.lock so installs are reproducible",
+		},
+		"unpinned Ruby": {
+			files: map[string]string{"Gemfile": "gem \"rake\"\n", "Gemfile.lock": "", "spec/app_spec.rb": ""},
+			path:  ".",
+			msg:   "Ruby version is not pinned. Add .ruby-version or a ruby directive in the Gemfile so local and CI runs use the same interpreter",
+		},
+		"no test layout": {
+			files: map[string]string{"Gemfile": "ruby \"3.3.0\"\n", "Gemfile.lock": "", "lib/app.rb": ""},
+			path:  ".",
+			msg:   "No Ruby test layout detected. Add spec/ (RSpec) or test/ (Minitest) to support CI validation",
+		},
+		"gemspec directive without gemspec": {
+			files: map[string]string{"Gemfile": "ruby \"3.3.0\"\ngemspec\n", "Gemfile.lock": "", "test/test_helper.rb": ""},
+			path:  "Gemfile",
+			msg:   "Gemfile calls gemspec but no *.gemspec exists at the root. Add the gemspec or remove the directive",
+		},
+		"incomplete gemspec": {
+			files: map[string]string{
+				"demo.gemspec": `Gem::Specification.new do |spec|
+  spec.name     = "demo"
+  spec.summary  = "A demo"
+  spec.licenses = ["MIT"]
+end
+`,
+				".ruby-version":     "3.3.0\n",
+				"spec/demo_spec.rb": "",
+			},
+			path: "demo.gemspec",
+			msg:  "demo.gemspec is missing gem metadata: homepage, required_ruby_version. Set them so RubyGems and users can evaluate the gem",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, RubyProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
+
+func TestRubyProjectCheck_VersionPinSources(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		".ruby-version":  {".ruby-version": "3.3.0\n"},
+		".tool-versions": {".tool-versions": "nodejs 20.0.0\nruby 3.3.0\n"},
+		"ruby file:":     {"Gemfile": "ruby file: \".ruby-version\"\n"},
+	} {
+		files["Gemfile.lock"] = ""
+		files["test/test_helper.rb"] = ""
+		if _, ok := files["Gemfile"]; !ok {
+			files["Gemfile"] = "gem \"rake\"\n"
+		}
+		if fs := runCheck(t, RubyProjectCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected pinned version, got %+v", name, fs)
+		}
+	}
+}
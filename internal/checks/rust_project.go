@@ -0,0 +1,222 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/toml"
+)
+
+// RustProjectCheck validates baseline conventions for Rust projects.
+//
+// Behavior
+//   - No-op when Cargo.toml is absent.
+//   - Parses the root Cargo.toml and every [workspace] member, and warns
+//     about members that match no crate on disk.
+//   - Warns when a binary crate is built without a committed Cargo.lock at
+//     the workspace root.
+//   - Warns about packages without edition or rust-version (MSRV), and about
+//     publishable packages without license/license-file or repository.
+//     Fields inherited with key.workspace = true are resolved against
+//     [workspace.package].
+type RustProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (RustProjectCheck) Key() string { return "rust_project" }
+
+// Description provides a short explanation of what this check validates.
+func (RustProjectCheck) Description() string {
+	return "Validates baseline conventions for Rust projects, including Cargo workspaces"
+}
+
+// cargoCrate is one parsed Cargo.toml: the root manifest or a workspace
+// member.
+type cargoCrate struct {
+	dir string // slash-separated, "." for the root
+	doc *toml.Document
+}
+
+// Run executes the Cargo validation.
+func (RustProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "Cargo.toml")
+	if err != nil {
+		// Not a Rust project, no-op.
+		return nil, nil
+	}
+
+	var findings []Finding
+	warn := func(dir string, line int, msg string) {
+		findings = append(findings, Finding{
+			Check:   "rust_project",
+			Level:   LevelWarn,
+			Path:    filepath.Join(root, filepath.FromSlash(dir), "Cargo.toml"),
+			Message: msg,
+			Line:    line,
+		})
+	}
+	parse := func(dir string, b []byte) *toml.Document {
+		doc, err := toml.Parse(b)
+		if err != nil {
+			f := Finding{
+				Check:   "rust_project",
+				Level:   LevelWarn,
+				Path:    filepath.Join(root, filepath.FromSlash(dir), "Cargo.toml"),
+				Message: "Cargo.toml is not valid TOML (" + err.Error() + "). Fix the syntax so cargo and this check can read it",
+			}
+			var te *toml.Error
+			if errors.As(err, &te) {
+				f.Line = te.Line
+			}
+			findings = append(findings, f)
+		}
+		return doc
+	}
+
+	rootDoc := parse(".", b)
+	if rootDoc == nil {
+		return findings, nil
+	}
+	workspace, _ := rootDoc.Root.Table("workspace")
+
+	crates := []cargoCrate{{dir: ".", doc: rootDoc}}
+	members, _ := workspace.Array("members")
+	for i, m := range members {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pattern, ok := m.(string)
+		if !ok {
+			continue
+		}
+		dirs := workspaceMembers(fsys, pattern, workspace)
+		if len(dirs) == 0 {
+			warn(".", rootDoc.Line("workspace", "members", strconv.Itoa(i)),
+				"workspace member "+pattern+" does not match any crate with a Cargo.toml. Fix or remove the entry")
+			continue
+		}
+		for _, dir := range dirs {
+			if dir == "." {
+				// The root package is already included.
+				continue
+			}
+			mb, err := fs.ReadFile(fsys, dir+"/Cargo.toml")
+			if err != nil {
+				continue
+			}
+			if doc := parse(dir, mb); doc != nil {
+				crates = append(crates, cargoCrate{dir: dir, doc: doc})
+			}
+		}
+	}
+
+	binary := false
+	for _, c := range crates {
+		pkg, ok := c.doc.Root.Table("package")
+		if !ok {
+			// A virtual manifest only declares the workspace.
+			continue
+		}
+		if isBinaryCrate(fsys, c) {
+			binary = true
+		}
+		name, _ := pkg.String("name")
+		if name == "" {
+			name = c.dir
+		}
+		line := c.doc.Line("package")
+		missing := func(key string) bool { return !cargoField(pkg, workspace, key) }
+
+		if missing("edition") {
+			warn(c.dir, line, "Package "+name+" does not set edition, so cargo falls back to Rust 2015. Add edition = \"2021\" or newer to [package]")
+		}
+		if missing("rust-version") {
+			warn(c.dir, line, "Package "+name+" does not declare rust-version. Add the minimum supported Rust version (MSRV) to [package]")
+		}
+		if !publishable(pkg) {
+			continue
+		}
+		if missing("license") && missing("license-file") {
+			warn(c.dir, line, "Publishable package "+name+" has no license or license-file. Add an SPDX license expression, or set publish = false")
+		}
+		if missing("repository") {
+			warn(c.dir, line, "Publishable package "+name+" has no repository URL. Add repository to [package], or set publish = false")
+		}
+	}
+
+	if binary && !exists(fsys, "Cargo.lock") {
+		warn(".", 0, "Binary crate without Cargo.lock. Commit Cargo.lock so builds are reproducible")
+	}
+	return findings, nil
+}
+
+// workspaceMembers expands one workspace.members entry to crate
+// directories, skipping workspace.exclude entries and matches without a
+// Cargo.toml.
+func workspaceMembers(fsys fs.FS, pattern string, workspace toml.Table) []string {
+	pattern = path.Clean(strings.TrimPrefix(pattern, "./"))
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil
+	}
+	excluded := map[string]bool{}
+	if ex, ok := workspace.Array("exclude"); ok {
+		for _, e := range ex {
+			if s, ok := e.(string); ok {
+				excluded[path.Clean(strings.TrimPrefix(s, "./"))] = true
+			}
+		}
+	}
+	var dirs []string
+	for _, m := range matches {
+		if !excluded[m] && exists(fsys, m+"/Cargo.toml") {
+			dirs = append(dirs, m)
+		}
+	}
+	return dirs
+}
+
+// cargoField reports whether a package sets key, either directly or by
+// inheriting it with key.workspace = true from [workspace.package].
+func cargoField(pkg, workspace toml.Table, key string) bool {
+	switch v := pkg[key].(type) {
+	case string:
+		return v != ""
+	case toml.Table:
+		if inherit, _ := v["workspace"].(bool); inherit {
+			s, _ := workspace.String("package", key)
+			return s != ""
+		}
+	}
+	return false
+}
+
+// publishable reports whether cargo publish would upload the package:
+// publish is unset, true, or a non-empty registry list.
+func publishable(pkg toml.Table) bool {
+	switch v := pkg["publish"].(type) {
+	case bool:
+		return v
+	case []any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// isBinaryCrate reports whether a crate builds an executable, through
+// src/main.rs, src/bin/, or explicit [[bin]] targets.
+func isBinaryCrate(fsys fs.FS, c cargoCrate) bool {
+	if bins, ok := c.doc.Root.Array("bin"); ok && len(bins) > 0 {
+		return true
+	}
+	prefix := ""
+	if c.dir != "." {
+		prefix = c.dir + "/"
+	}
+	return exists(fsys, prefix+"src/main.rs") || isDir(fsys, prefix+"src/bin")
+}
@@ -0,0 +1,123 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+)
+
+const completeCargoPackage = `[package]
+name = "app"
+version = "0.1.0"
+edition = "2021"
+rust-version = "1.74"
+license = "MIT"
+repository = "https://github.com/acme/app"
+`
+
+func TestRustProjectCheck_NoCargo_NoOp(t *testing.T) {
+	if fs := runCheck(t, RustProjectCheck{}, map[string]string{"go.mod": "module x\n"}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestRustProjectCheck_CompleteBinaryCratePasses(t *testing.T) {
+	fs := runCheck(t, RustProjectCheck{}, map[string]string{
+		"Cargo.toml":  completeCargoPackage,
+		"Cargo.lock":  "version = 3\n",
+		"src/main.rs": "fn main() {}\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestRustProjectCheck_BinaryWithoutLockfile(t *testing.T) {
+	fs := runCheck(t, RustProjectCheck{}, map[string]string{
+		"Cargo.toml":     completeCargoPackage,
+		"src/bin/x.rs":   "fn main() {}\n",
+		"src/lib.rs":     "",
+		"lib/Cargo.toml": "not a member\n",
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "without Cargo.lock") {
+		t.Fatalf("expected Cargo.lock finding, got %+v", fs)
+	}
+
+	// Libraries conventionally leave Cargo.lock out.
+	fs = runCheck(t, RustProjectCheck{}, map[string]string{"Cargo.toml": completeCargoPackage, "src/lib.rs": ""})
+	if len(fs) != 0 {
+		t.Fatalf("expected library without Cargo.lock to pass, got %+v", fs)
+	}
+}
+
+func TestRustProjectCheck_MissingMetadata(t *testing.T) {
+	fs := runCheck(t, RustProjectCheck{}, map[string]string{
+		"Cargo.toml": "# demo\n[package]\nname = \"app\"\nversion = \"0.1.0\"\n",
+		"src/lib.rs": "",
+	})
+	var msgs []string
+	for _, f := range fs {
+		if f.Line != 2 {
+			t.Fatalf("expected findings on the [package] line, got %+v", f)
+		}
+		msgs = append(msgs, f.Message)
+	}
+	got := strings.Join(msgs, "\n")
+	for _, want := range []string{"does not set edition", "does not declare rust-version", "no license or license-file", "no repository URL"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in:\n%s", want, got)
+		}
+	}
+
+	// Private crates need no publishing metadata.
+	fs = runCheck(t, RustProjectCheck{}, map[string]string{
+		"Cargo.toml": "[package]\nname = \"app\"\nedition = \"2021\"\nrust-version = \"1.74\"\npublish = false\n",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected unpublished crate to pass, got %+v", fs)
+	}
+}
+
+func TestRustProjectCheck_Workspace(t *testing.T) {
+	fs := runCheck(t, RustProjectCheck{}, map[string]string{
+		"Cargo.toml": `[workspace]
+members = [
+  "crates/*",
+  "tools/missing",
+]
+exclude = ["crates/scratch"]
+
+[workspace.package]
+edition = "2021"
+rust-version = "1.74"
+license = "Apache-2.0"
+`,
+		"crates/cli/Cargo.toml":     "[package]\nname = \"cli\"\nedition.workspace = true\nrust-version.workspace = true\nlicense.workspace = true\nrepository.workspace = true\n",
+		"crates/cli/src/main.rs":    "fn main() {}\n",
+		"crates/core/Cargo.toml":    "[package]\nname = \"core\"\nedition.workspace = true\nrust-version = \"1.70\"\npublish = false\n",
+		"crates/scratch/Cargo.toml": "[package]\nname = \"scratch\"\n",
+		"crates/README.md":          "not a crate\n",
+	})
+	var msgs []string
+	for _, f := range fs {
+		msgs = append(msgs, f.Path+": "+f.Message)
+	}
+	got := strings.Join(msgs, "\n")
+	if len(fs) != 3 ||
+		!strings.Contains(got, "workspace member tools/missing does not match any crate") ||
+		!strings.Contains(got, "cli has no repository URL") ||
+		!strings.Contains(got, "Binary crate without Cargo.lock") {
+		t.Fatalf("unexpected findings:\n%s", got)
+	}
+	for _, f := range fs {
+		if strings.Contains(f.Message, "tools/missing") && f.Line != 4 {
+			t.Fatalf("expected member finding on line 4, got %+v", f)
+		}
+	}
+}
+
+func TestRustProjectCheck_InvalidToml(t *testing.T) {
+	fs := runCheck(t, RustProjectCheck{}, map[string]string{"Cargo.toml": "[package]\nname = \"app\nedition = \"2021\"\n"})
+	if len(fs) != 1 || fs[0].Line != 2 || !strings.Contains(fs[0].Message, "not valid TOML") {
+		t.Fatalf("expected syntax finding on line 2, got %+v", fs)
+	}
+}
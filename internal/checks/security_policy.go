@@ -2,7 +2,6 @@ package checks
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 )
 
@@ -16,12 +15,9 @@ func (SecurityPolicyCheck) Description() string {
 }
 
 func (SecurityPolicyCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-	candidates := []string{
-		filepath.Join(root, "SECURITY.md"),
-		filepath.Join(root, ".github", "SECURITY.md"),
-	}
-	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
+	fsys := opts.fsys(root)
+	for _, name := range []string{"SECURITY.md", ".github/SECURITY.md"} {
+		if exists(fsys, name) {
 			return nil, nil
 		}
 	}
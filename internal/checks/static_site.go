@@ -1,86 +1,107 @@
 package checks
 
 import (
-    "context"
-    "os"
-    "path/filepath"
+	"context"
+	"io/fs"
+	"path/filepath"
 )
 
 // StaticSiteCheck validates minimal structure for static-site projects.
 // Currently focuses on Jekyll-style repos (detected via _config.yml).
 // If no static-site config is detected, this check is a no-op.
+//
+// The expected layout defaults to index.md, pages/, and assets/, and can be
+// changed per repository with the index, pages, and assets settings. Setting
+// one of them to an empty string skips that requirement.
 type StaticSiteCheck struct{}
 
 func (StaticSiteCheck) Key() string { return "static_site" }
 
 func (StaticSiteCheck) Description() string {
-    return "Validates minimal structure for static-site projects (e.g., Jekyll)"
+	return "Validates minimal structure for static-site projects (e.g., Jekyll)"
+}
+
+// Schema declares the settings accepted in the config file.
+func (StaticSiteCheck) Schema() []Setting {
+	return []Setting{
+		{Name: "index", Kind: SettingString, Description: "landing page file, default index.md"},
+		{Name: "pages", Kind: SettingString, Description: "directory holding markdown pages, default pages"},
+		{Name: "assets", Kind: SettingString, Description: "directory holding static files, default assets"},
+	}
 }
 
 func (StaticSiteCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
-    // Jekyll detection: _config.yml at repo root
-    jekyllCfg := filepath.Join(root, "_config.yml")
-    if _, err := os.Stat(jekyllCfg); err != nil {
-        // Not a static-site project we recognize; no-op
-        return nil, nil
-    }
+	// Jekyll detection: _config.yml at repo root
+	fsys := opts.fsys(root)
+	if !exists(fsys, "_config.yml") {
+		// Not a static-site project we recognize; no-op
+		return nil, nil
+	}
 
-    var out []Finding
+	index := opts.Settings.String("index", "index.md")
+	pages := opts.Settings.String("pages", "pages")
+	assets := opts.Settings.String("assets", "assets")
 
-    // Require a landing page
-    idx := filepath.Join(root, "index.md")
-    if _, err := os.Stat(idx); err != nil {
-        out = append(out, Finding{
-            Check:   "static_site",
-            Level:   LevelWarn,
-            Path:    root,
-            Message: "index.md missing. Add a landing page for the site",
-        })
-    }
+	var out []Finding
 
-    // Recommend a pages/ directory with at least one markdown file
-    pagesDir := filepath.Join(root, "pages")
-    if st, err := os.Stat(pagesDir); err != nil || !st.IsDir() {
-        out = append(out, Finding{
-            Check:   "static_site",
-            Level:   LevelWarn,
-            Path:    pagesDir,
-            Message: "pages/ directory missing. Create pages/ with markdown content",
-        })
-    } else {
-        entries, _ := os.ReadDir(pagesDir)
-        hasMD := false
-        for _, e := range entries {
-            if e.IsDir() {
-                continue
-            }
-            name := e.Name()
-            if filepath.Ext(name) == ".md" || filepath.Ext(name) == ".markdown" {
-                hasMD = true
-                break
-            }
-        }
-        if !hasMD {
-            out = append(out, Finding{
-                Check:   "static_site",
-                Level:   LevelWarn,
-                Path:    pagesDir,
-                Message: "pages/ has no markdown files. Add at least one .md page",
-            })
-        }
-    }
+	// Require a landing page
+	if index != "" {
+		if !exists(fsys, cleanName(index)) {
+			out = append(out, Finding{
+				Check:   "static_site",
+				Level:   LevelWarn,
+				Path:    root,
+				Message: index + " missing. Add a landing page for the site",
+			})
+		}
+	}
 
-    // Recommend an assets/ directory for static files
-    assetsDir := filepath.Join(root, "assets")
-    if st, err := os.Stat(assetsDir); err != nil || !st.IsDir() {
-        out = append(out, Finding{
-            Check:   "static_site",
-            Level:   LevelWarn,
-            Path:    assetsDir,
-            Message: "assets/ directory missing. Add assets/ for images, CSS, and JS",
-        })
-    }
+	// Recommend a pages directory with at least one markdown file
+	if pages != "" {
+		pagesDir := filepath.Join(root, filepath.FromSlash(pages))
+		if !isDir(fsys, cleanName(pages)) {
+			out = append(out, Finding{
+				Check:   "static_site",
+				Level:   LevelWarn,
+				Path:    pagesDir,
+				Message: pages + "/ directory missing. Create " + pages + "/ with markdown content",
+			})
+		} else {
+			entries, _ := fs.ReadDir(fsys, cleanName(pages))
+			hasMD := false
+			for _, e := range entries {
+				if e.IsDir() {
+					continue
+				}
+				name := e.Name()
+				if filepath.Ext(name) == ".md" || filepath.Ext(name) == ".markdown" {
+					hasMD = true
+					break
+				}
+			}
+			if !hasMD {
+				out = append(out, Finding{
+					Check:   "static_site",
+					Level:   LevelWarn,
+					Path:    pagesDir,
+					Message: pages + "/ has no markdown files. Add at least one .md page",
+				})
+			}
+		}
+	}
 
-    return out, nil
-}
+	// Recommend an assets directory for static files
+	if assets != "" {
+		assetsDir := filepath.Join(root, filepath.FromSlash(assets))
+		if !isDir(fsys, cleanName(assets)) {
+			out = append(out, Finding{
+				Check:   "static_site",
+				Level:   LevelWarn,
+				Path:    assetsDir,
+				Message: assets + "/ directory missing. Add " + assets + "/ for images, CSS, and JS",
+			})
+		}
+	}
 
+	return out, nil
+}
@@ -57,3 +57,29 @@ func TestStaticSiteCheck_Jekyll_MinimalOK(t *testing.T) {
 		t.Fatalf("expected no warnings for minimal structure, got %d", len(fs))
 	}
 }
+
+func TestStaticSiteCheck_ConfiguredLayout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "_config.yml"), []byte("title: site"), 0o644); err != nil {
+		t.Fatalf("write _config.yml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.md"), []byte("# Home"), 0o644); err != nil {
+		t.Fatalf("write index.md: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "content"), 0o750); err != nil {
+		t.Fatalf("mkdir content: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "content", "about.md"), []byte("# About"), 0o644); err != nil {
+		t.Fatalf("write about.md: %v", err)
+	}
+
+	// content/ replaces pages/, and an empty assets setting skips that requirement.
+	opts := Options{Settings: Settings{"pages": "content", "assets": ""}}
+	fs, err := (StaticSiteCheck{}).Run(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings for configured layout, got %+v", fs)
+	}
+}
@@ -0,0 +1,62 @@
+package checks
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+)
+
+// SwiftProjectCheck validates baseline conventions for Swift packages.
+//
+// Behavior
+//   - No-op when Package.swift is absent.
+//   - Warns when Package.resolved is missing.
+//   - Warns when Package.swift does not start with a swift-tools-version
+//     comment, which pins the minimum Swift toolchain.
+//   - Warns when there is no Tests/ directory.
+type SwiftProjectCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (SwiftProjectCheck) Key() string { return "swift_project" }
+
+// Description provides a short explanation of what this check validates.
+func (SwiftProjectCheck) Description() string {
+	return "Validates lockfiles, toolchain pinning, and test layout for Swift packages"
+}
+
+// swiftToolsVersion matches the tools version comment SwiftPM requires on
+// the first line, e.g. // swift-tools-version:5.9.
+var swiftToolsVersion = regexp.MustCompile(`\A\s*//\s*swift-tools-version\s*:\s*\d`)
+
+// Run executes the Swift package validation.
+func (SwiftProjectCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	b, err := fs.ReadFile(fsys, "Package.swift")
+	if err != nil {
+		// Not a Swift package, no-op.
+		return nil, nil
+	}
+	manifest := filepath.Join(root, "Package.swift")
+
+	var findings []Finding
+	warn := func(path, msg string) {
+		findings = append(findings, Finding{Check: "swift_project", Level: LevelWarn, Path: path, Message: msg})
+	}
+	if !exists(fsys, "Package.resolved") {
+		warn(manifest, "Package.swift found without Package.resolved. Run swift package resolve and commit Package.resolved so dependency versions are reproducible")
+	}
+	if !swiftToolsVersion.Match(b) {
+		findings = append(findings, Finding{
+			Check:   "swift_project",
+			Level:   LevelWarn,
+			Path:    manifest,
+			Message: "Package.swift does not start with a swift-tools-version comment. Add // swift-tools-version:<version> to pin the minimum Swift toolchain",
+			Line:    1,
+		})
+	}
+	if !isDir(fsys, "Tests") {
+		warn(root, "No Tests/ directory detected. Add test targets under Tests/ to support CI validation")
+	}
+	return findings, nil
+}
@@ -0,0 +1,48 @@
+package checks
+
+import "testing"
+
+func TestSwiftProjectCheck_NoPackage_NoOp(t *testing.T) {
+	if fs := runCheck(t, SwiftProjectCheck{}, map[string]string{"README.md": ""}); len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestSwiftProjectCheck_ConventionalPackagePasses(t *testing.T) {
+	fs := runCheck(t, SwiftProjectCheck{}, map[string]string{
+		"Package.swift":                   "// swift-tools-version: 5.9\nimport PackageDescription\n",
+		"Package.resolved":                "{}",
+		"Tests/DemoTests/DemoTests.swift": "",
+	})
+	if len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v", fs)
+	}
+}
+
+func TestSwiftProjectCheck_Rules(t *testing.T) {
+	const manifest = "// swift-tools-version: 5.9\nimport PackageDescription\n"
+	for name, tc := range map[string]struct {
+		files     map[string]string
+		path, msg string
+	}{
+		"missing Package.resolved": {
+			files: map[string]string{"Package.swift": manifest, "Tests/DemoTests/DemoTests.swift": ""},
+			path:  "Package.swift",
+			msg:   "Package.swift found without Package.resolved. Run swift package resolve and commit Package.resolved so dependency versions are reproducible",
+		},
+		"tools version not on the first line": {
+			files: map[string]string{"Package.swift": "import PackageDescription\n" + manifest, "Package.resolved": "{}", "Tests/DemoTests/DemoTests.swift": ""},
+			path:  "Package.swift",
+			msg:   "Package.swift does not start with a swift-tools-version comment. Add // swift-tools-version:<version> to pin the minimum Swift toolchain",
+		},
+		"no Tests directory": {
+			files: map[string]string{"Package.swift": manifest, "Package.resolved": "{}", "tests/DemoTests.swift": ""},
+			path:  ".",
+			msg:   "No Tests/ directory detected. Add test targets under Tests/ to support CI validation",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectFinding(t, SwiftProjectCheck{}, tc.files, tc.path, tc.msg)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/git"
+	"github.com/hittegit/yardstick/internal/gitignore"
+)
+
+// TrackedFilesCheck compares the git index with .gitignore. Files committed
+// before a pattern was added stay tracked, so node_modules/, .env, or dist/
+// can keep shipping even though .gitignore looks right.
+//
+// Behavior
+//   - Each ignored path with tracked files is a warning; a tracked file
+//     under an ignored directory is reported once, as that directory.
+//   - Untracked files that .gitignore does not cover are summarized in a
+//     single info finding.
+//   - Trees without .git (archives, -rev scans) are skipped. A .git file, as
+//     in linked worktrees and submodules, is followed to its git directory
+//     in directory scans; elsewhere an info finding notes the skip.
+type TrackedFilesCheck struct{}
+
+func (TrackedFilesCheck) Key() string { return "tracked_files" }
+
+func (TrackedFilesCheck) Description() string {
+	return "Flags committed files that .gitignore ignores and untracked files it does not cover"
+}
+
+// maxListedUntracked caps how many untracked paths the info finding names.
+const maxListedUntracked = 10
+
+func (TrackedFilesCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	data, err := fs.ReadFile(fsys, ".git/index")
+	if st, statErr := fs.Stat(fsys, ".git"); err != nil && statErr == nil && !st.IsDir() {
+		// Linked worktrees and submodules keep their index in the git
+		// directory their .git file points at.
+		data, err = linkedGitIndex(opts, root)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return []Finding{{
+				Check:   "tracked_files",
+				Level:   LevelInfo,
+				Path:    filepath.Join(root, ".git"),
+				Message: "Skipped: " + err.Error(),
+			}}, nil
+		}
+	}
+	if err != nil {
+		// Not a working tree, or nothing has ever been staged.
+		return nil, nil
+	}
+	entries, err := git.ReadIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	ign := gitignore.New(fsys)
+
+	// tracked holds tracked paths; trackedDirs every directory holding one.
+	tracked := make(map[string]bool, len(entries))
+	trackedDirs := map[string]bool{".": true}
+	ignored := map[string]int{}
+	var order []string
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		tracked[e.Path] = true
+		for dir := path.Dir(e.Path); !trackedDirs[dir]; dir = path.Dir(dir) {
+			trackedDirs[dir] = true
+		}
+		if top, ok := outermostIgnored(ign, e.Path, e.Mode == git.ModeSubmodule); ok {
+			if ignored[top] == 0 {
+				order = append(order, top)
+			}
+			ignored[top]++
+		}
+	}
+
+	var findings []Finding
+	for _, top := range order {
+		name := strings.TrimSuffix(top, "/")
+		msg := fmt.Sprintf("%s is ignored by .gitignore but committed. Untrack it with git rm --cached %s", top, name)
+		if top != name {
+			msg = fmt.Sprintf("%s is ignored by .gitignore but %d tracked files are committed under it. Untrack them with git rm -r --cached %s", top, ignored[top], name)
+		}
+		findings = append(findings, Finding{
+			Check:   "tracked_files",
+			Level:   LevelWarn,
+			Path:    filepath.Join(root, filepath.FromSlash(name)),
+			Message: msg,
+		})
+	}
+
+	untracked, err := untrackedPaths(ctx, fsys, ign, tracked, trackedDirs)
+	if err != nil {
+		return nil, err
+	}
+	if len(untracked) > 0 {
+		listed := untracked[:min(len(untracked), maxListedUntracked)]
+		msg := fmt.Sprintf("%d untracked paths are not covered by .gitignore: %s", len(untracked), strings.Join(listed, ", "))
+		if more := len(untracked) - len(listed); more > 0 {
+			msg += fmt.Sprintf(", and %d more", more)
+		}
+		findings = append(findings, Finding{
+			Check:   "tracked_files",
+			Level:   LevelInfo,
+			Path:    root,
+			Message: msg + ". Commit them or add them to .gitignore",
+		})
+	}
+	return findings, nil
+}
+
+// linkedGitIndex reads the index from the git directory a .git file points
+// at. It lies outside the scanned tree, so only a directory scan can reach
+// it.
+func linkedGitIndex(opts Options, root string) ([]byte, error) {
+	if !opts.onDisk(root) {
+		return nil, errors.New(".git is a file pointing at a git directory outside this tree, so the git index cannot be read")
+	}
+	dir, err := git.Dir(root)
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G304 -- the git directory belongs to the user-selected working tree.
+	return os.ReadFile(filepath.Join(dir, "index"))
+}
+
+// outermostIgnored returns the highest ignored path covering the tracked
+// path p, with a trailing slash when it is a directory.
+func outermostIgnored(ign *gitignore.Matcher, p string, isDir bool) (string, bool) {
+	for i := range len(p) {
+		if p[i] == '/' && ign.Match(p[:i], true) {
+			return p[:i] + "/", true
+		}
+	}
+	if ign.Match(p, isDir) {
+		if isDir {
+			return p + "/", true
+		}
+		return p, true
+	}
+	return "", false
+}
+
+// errFound stops a walk at its first hit.
+var errFound = errors.New("found")
+
+// untrackedPaths lists what git status would show as untracked: files
+// outside the index that .gitignore does not cover, with directories that
+// hold no tracked files collapsed to "dir/".
+func untrackedPaths(ctx context.Context, fsys fs.FS, ign *gitignore.Matcher, tracked, trackedDirs map[string]bool) ([]string, error) {
+	var out []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if name == "." || err != nil {
+			// Unreadable subdirectories are skipped, not fatal.
+			if name == "." {
+				return err
+			}
+			return nil
+		}
+		if name == ".git" || ign.Match(name, d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case tracked[name]:
+			// Includes submodules, whose contents are not ours to judge.
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+		case d.IsDir() && !trackedDirs[name]:
+			if hasUnignoredFile(fsys, ign, name) {
+				out = append(out, name+"/")
+			}
+			return fs.SkipDir
+		case !d.IsDir():
+			out = append(out, name)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// hasUnignoredFile reports whether dir holds any file .gitignore does not
+// cover; git does not list directories with nothing to add.
+func hasUnignoredFile(fsys fs.FS, ign *gitignore.Matcher, dir string) bool {
+	err := fs.WalkDir(fsys, dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || name == dir {
+			return err
+		}
+		if ign.Match(name, d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return errFound
+		}
+		return nil
+	})
+	return errors.Is(err, errFound)
+}
@@ -0,0 +1,131 @@
+package checks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func gitRepo(t *testing.T, files map[string]string) (string, func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	for name, body := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir, git
+}
+
+func TestTrackedFilesCheck_NotAGitTreeNoop(t *testing.T) {
+	fs, err := (TrackedFilesCheck{}).Run(context.Background(), t.TempDir(), Options{})
+	if err != nil || len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v %v", fs, err)
+	}
+}
+
+func TestTrackedFilesCheck_CleanRepoPasses(t *testing.T) {
+	dir, git := gitRepo(t, map[string]string{
+		".gitignore":           "dist/\n",
+		"README.md":            "# r\n",
+		"dist/bundle.js":       "",
+		"src/app/component.js": "",
+	})
+	git("add", "-A")
+
+	fs, err := (TrackedFilesCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil || len(fs) != 0 {
+		t.Fatalf("expected no findings, got %+v %v", fs, err)
+	}
+}
+
+func TestTrackedFilesCheck_TrackedButIgnoredAndUntracked(t *testing.T) {
+	dir, git := gitRepo(t, map[string]string{
+		"README.md":               "# r\n",
+		".env":                    "TOKEN=x\n",
+		"node_modules/a/index.js": "",
+		"node_modules/b/index.js": "",
+		"src/main.js":             "",
+		"notes.txt":               "",
+		"scratch/todo.md":         "",
+		"scratch/ignored.log":     "",
+		"logs/only.log":           "",
+		"src/new.js":              "",
+	})
+	git("add", "README.md", ".env", "node_modules", "src/main.js")
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(".env\nnode_modules/\n*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".gitignore")
+
+	fs, err := (TrackedFilesCheck{}).Run(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 3 {
+		t.Fatalf("expected 3 findings, got %+v", fs)
+	}
+	if fs[0].Level != LevelWarn || fs[0].Path != filepath.Join(dir, ".env") || !strings.Contains(fs[0].Message, "git rm --cached .env") {
+		t.Fatalf("unexpected .env finding: %+v", fs[0])
+	}
+	if fs[1].Level != LevelWarn || fs[1].Path != filepath.Join(dir, "node_modules") || !strings.Contains(fs[1].Message, "node_modules/ is ignored by .gitignore but 2 tracked files") {
+		t.Fatalf("unexpected node_modules finding: %+v", fs[1])
+	}
+	// logs/ holds only ignored files, so git would not list it.
+	want := "3 untracked paths are not covered by .gitignore: notes.txt, scratch/, src/new.js"
+	if fs[2].Level != LevelInfo || !strings.HasPrefix(fs[2].Message, want) {
+		t.Fatalf("unexpected untracked finding: %+v", fs[2])
+	}
+}
+
+func TestTrackedFilesCheck_LinkedWorktree(t *testing.T) {
+	_, git := gitRepo(t, map[string]string{
+		".gitignore":     "dist/\n",
+		"README.md":      "# r\n",
+		"dist/bundle.js": "",
+	})
+	git("add", "-A")
+	git("add", "-f", "dist/bundle.js")
+	git("-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "-m", "init")
+	wt := filepath.Join(t.TempDir(), "wt")
+	git("worktree", "add", "-q", wt)
+
+	fs, err := (TrackedFilesCheck{}).Run(context.Background(), wt, Options{})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Path != filepath.Join(wt, "dist") || !strings.HasPrefix(fs[0].Message, "dist/ is ignored by .gitignore") {
+		t.Fatalf("expected the worktree index to be read, got %+v", fs)
+	}
+
+	// Outside a directory scan the pointer cannot be followed.
+	tree := fstest.MapFS{".git": {Data: []byte("gitdir: ../main/.git/worktrees/wt\n")}}
+	fs, err = (TrackedFilesCheck{}).Run(context.Background(), "wt.tar.gz", Options{FS: tree})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || fs[0].Level != LevelInfo || !strings.HasPrefix(fs[0].Message, "Skipped: .git is a file") {
+		t.Fatalf("expected a skipped info finding, got %+v", fs)
+	}
+}
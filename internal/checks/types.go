@@ -1,6 +1,9 @@
 package checks
 
-import "context"
+import (
+	"context"
+	"io/fs"
+)
 
 // Level represents the severity level of a finding.
 // Each check can report results at different levels depending on importance.
@@ -26,8 +29,9 @@ type Finding struct {
 	// Level indicates how severe the finding is (info, warn, or error).
 	Level Level `json:"level"`
 
-	// Path points to the file or directory the finding concerns.
-	// For project-wide findings, this may be the repository root path.
+	// Path points to the file or directory the finding concerns, joined
+	// onto the root passed to Run. For project-wide findings, this may be
+	// the repository root path.
 	Path string `json:"path"`
 
 	// Message provides a short human-readable description of the issue.
@@ -35,6 +39,21 @@ type Finding struct {
 
 	// Fixed is true if the issue was automatically corrected when --fix was used.
 	Fixed bool `json:"fixed"`
+
+	// Suppressed is true when the finding matches an entry in the -baseline
+	// file. Suppressed findings are reported but do not affect exit codes.
+	Suppressed bool `json:"suppressed,omitempty"`
+
+	// Line and Column locate the start of the issue within Path, both
+	// 1-based, with columns counted in Unicode code points. Zero means the
+	// finding applies to the whole file.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// EndLine and EndColumn mark the position just past the end of the
+	// issue, when the check knows its extent.
+	EndLine   int `json:"end_line,omitempty"`
+	EndColumn int `json:"end_column,omitempty"`
 }
 
 // Options contains runtime flags passed into each check.
@@ -43,6 +62,69 @@ type Options struct {
 	// AutoFix is reserved for potential future use. Yardstick is read-only
 	// and does not write; checks must not modify files regardless of this flag.
 	AutoFix bool
+
+	// Settings holds the configured per-check settings for the check being
+	// run, already validated against its Schema. Nil means all defaults.
+	Settings Settings
+
+	// FS is the tree being scanned, with slash-separated names relative to
+	// the repository root. The CLI passes the run's shared repo.Index, which
+	// may come from a directory or an archive. Checks read the tree through
+	// it (see Options.fsys) instead of the disk; nil falls back to the live
+	// directory at root.
+	FS fs.FS
+}
+
+// SettingKind is the value type a check setting accepts.
+type SettingKind string
+
+// Supported setting kinds. Settings values decode to string, []string, and
+// bool respectively.
+const (
+	SettingString     SettingKind = "string"
+	SettingStringList SettingKind = "string list"
+	SettingBool       SettingKind = "bool"
+)
+
+// Setting declares one configurable parameter of a check.
+type Setting struct {
+	Name        string
+	Kind        SettingKind
+	Description string
+}
+
+// Configurable is implemented by checks that accept per-check settings.
+// The schema is used to validate the settings block in the config file
+// before any check runs, so Run can rely on values having the declared kind.
+type Configurable interface {
+	Schema() []Setting
+}
+
+// Settings maps a setting name to its decoded value.
+type Settings map[string]any
+
+// String returns a string setting, or def when it is not configured.
+func (s Settings) String(name, def string) string {
+	if v, ok := s[name].(string); ok {
+		return v
+	}
+	return def
+}
+
+// StringList returns a string list setting, or def when it is not configured.
+func (s Settings) StringList(name string, def []string) []string {
+	if v, ok := s[name].([]string); ok {
+		return v
+	}
+	return def
+}
+
+// Bool returns a bool setting, or def when it is not configured.
+func (s Settings) Bool(name string, def bool) bool {
+	if v, ok := s[name].(bool); ok {
+		return v
+	}
+	return def
 }
 
 // Check is the interface that all yardstick checks must implement.
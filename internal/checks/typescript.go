@@ -0,0 +1,380 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/gitignore"
+)
+
+// TypeScriptCheck validates tsconfig.json for projects that depend on
+// TypeScript.
+//
+// Behavior
+//   - No-op unless package.json lists typescript in dependencies or
+//     devDependencies.
+//   - Warns when tsconfig.json is missing or does not parse as JSONC (JSON
+//     with comments and trailing commas), with the error position.
+//   - Warns when compilerOptions.strict is not enabled, following extends.
+//   - Warns when an extends target does not resolve to a file in the
+//     repository or an installed package. Package targets are accepted
+//     without node_modules when the package is a declared dependency,
+//     since scans often run before install.
+//   - Warns about files entries that do not exist and include patterns
+//     that match no source file.
+type TypeScriptCheck struct{}
+
+// Key returns the unique identifier for this check.
+func (TypeScriptCheck) Key() string { return "typescript" }
+
+// Description provides a short explanation of what this check validates.
+func (TypeScriptCheck) Description() string {
+	return "Validates tsconfig.json for TypeScript projects: strict mode, extends, include, and files"
+}
+
+// tsconfig is the subset of tsconfig.json the check reads.
+type tsconfig struct {
+	// Extends is a string or, since TypeScript 5.0, a list of strings.
+	Extends         json.RawMessage `json:"extends"`
+	CompilerOptions struct {
+		Strict  *bool `json:"strict"`
+		AllowJS bool  `json:"allowJs"`
+	} `json:"compilerOptions"`
+	Include []string `json:"include"`
+	Files   []string `json:"files"`
+}
+
+// maxExtendsDepth bounds extends chains, which may also be cyclic.
+const maxExtendsDepth = 10
+
+// Run executes the tsconfig validation.
+func (TypeScriptCheck) Run(ctx context.Context, root string, opts Options) ([]Finding, error) {
+	fsys := opts.fsys(root)
+	pb, err := fs.ReadFile(fsys, "package.json")
+	if err != nil {
+		return nil, nil
+	}
+	var pkg packageJSON
+	if json.Unmarshal(pb, &pkg) != nil || !hasDep(pkg.Dependencies, "typescript") && !hasDep(pkg.DevDependencies, "typescript") {
+		// Not a TypeScript project, or package.json is reported by
+		// javascript_framework.
+		return nil, nil
+	}
+
+	tsPath := filepath.Join(root, "tsconfig.json")
+	b, err := fs.ReadFile(fsys, "tsconfig.json")
+	if err != nil {
+		return []Finding{{
+			Check:   "typescript",
+			Level:   LevelWarn,
+			Path:    tsPath,
+			Message: "typescript is a dependency but tsconfig.json is missing. Run tsc --init and enable strict",
+		}}, nil
+	}
+	src := string(b)
+	cfg, err := parseTSConfig(b)
+	if err != nil {
+		f := Finding{
+			Check:   "typescript",
+			Level:   LevelWarn,
+			Path:    tsPath,
+			Message: "tsconfig.json does not parse. Fix the syntax so tsc and this check can read it",
+		}
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			f.Line, f.Column = position(src, int(syn.Offset)-1)
+		}
+		return []Finding{f}, nil
+	}
+
+	var findings []Finding
+	// warnAt spans the first occurrence of token within the value of the
+	// top-level key, or within the whole file when key is "". Searching the
+	// stripped source keeps comments from matching.
+	stripped := string(stripJSONC(b))
+	warnAt := func(key, token, msg string) {
+		f := Finding{Check: "typescript", Level: LevelWarn, Path: tsPath, Message: msg}
+		start, end := 0, len(stripped)
+		if key != "" {
+			start, end = jsonValueRange(stripped, key)
+		}
+		if i := strings.Index(stripped[start:end], token); i >= 0 {
+			f = withSpan(f, src, start+i, start+i+len(token))
+		}
+		findings = append(findings, f)
+	}
+
+	deps := func(name string) bool { return hasDep(pkg.Dependencies, name) || hasDep(pkg.DevDependencies, name) }
+	resolved := true
+	for _, ext := range tsExtends(cfg) {
+		if _, ok := resolveTSExtends(fsys, ".", ext, deps); !ok {
+			resolved = false
+			warnAt("extends", `"`+ext+`"`, "extends target "+ext+" does not resolve to a file or installed package. Fix the path or add the package to devDependencies")
+		}
+	}
+	// An unresolved base could be the one enabling strict; the extends
+	// finding covers it.
+	if resolved && !tsStrict(fsys, ".", cfg, deps, 0) {
+		key, token := "", `"compilerOptions"`
+		if cfg.CompilerOptions.Strict != nil {
+			key, token = "compilerOptions", `"strict"`
+		}
+		warnAt(key, token, "compilerOptions.strict is not enabled. Set \"strict\": true to catch type errors tsc otherwise allows")
+	}
+
+	for _, f := range cfg.Files {
+		if !exists(fsys, cleanName(f)) {
+			warnAt("files", `"`+f+`"`, "files entry "+f+" does not exist. Remove it or fix the path")
+		}
+	}
+	if len(cfg.Include) > 0 {
+		sources, err := tsSourceFiles(ctx, fsys, cfg.CompilerOptions.AllowJS)
+		if err != nil {
+			return nil, err
+		}
+		for _, pattern := range cfg.Include {
+			if !tsIncludeMatches(pattern, sources) {
+				warnAt("include", `"`+pattern+`"`, "include pattern "+pattern+" matches no source files. Remove it or fix the pattern")
+			}
+		}
+	}
+	return findings, nil
+}
+
+// parseTSConfig decodes tsconfig JSONC. Comments and trailing commas are
+// blanked out in place, so syntax error offsets still match the source.
+func parseTSConfig(b []byte) (tsconfig, error) {
+	var cfg tsconfig
+	err := json.Unmarshal(stripJSONC(b), &cfg)
+	return cfg, err
+}
+
+// stripJSONC replaces comments and trailing commas with spaces, keeping
+// line breaks and byte offsets intact.
+func stripJSONC(b []byte) []byte {
+	out := append([]byte(nil), b...)
+	inString := false
+	lastComma := -1
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString, lastComma = true, -1
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := strings.Index(string(out[i+2:]), "*/")
+			stop := len(out)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		case c == ',':
+			lastComma = i
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			lastComma = -1
+		}
+	}
+	return out
+}
+
+// jsonValueRange returns the byte range of the value of a top-level key in
+// a JSON object, or 0, 0 when the key is absent. Like encoding/json, the
+// last of duplicate keys wins.
+func jsonValueRange(src, key string) (int, int) {
+	dec := json.NewDecoder(strings.NewReader(src))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return 0, 0
+	}
+	start, end := 0, 0
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return 0, 0
+		}
+		from := int(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, 0
+		}
+		if t == key {
+			start, end = from, int(dec.InputOffset())
+		}
+	}
+	return start, end
+}
+
+// tsExtends returns the extends targets of cfg in declaration order.
+func tsExtends(cfg tsconfig) []string {
+	var one string
+	if json.Unmarshal(cfg.Extends, &one) == nil {
+		return []string{one}
+	}
+	var many []string
+	_ = json.Unmarshal(cfg.Extends, &many)
+	return many
+}
+
+// resolveTSExtends resolves an extends target relative to the directory
+// of the config naming it. It returns the resolved file, or "" when a
+// declared package cannot be read because dependencies are not installed.
+func resolveTSExtends(fsys fs.FS, dir, target string, declared func(string) bool) (string, bool) {
+	candidates := func(p string) []string {
+		if strings.HasSuffix(p, ".json") {
+			return []string{p}
+		}
+		return []string{p, p + ".json", p + "/tsconfig.json"}
+	}
+	if strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") || strings.HasPrefix(target, "/") {
+		rel := path.Join(dir, target)
+		if rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(target) {
+			// Outside the repository; nothing a clean checkout can use.
+			return "", false
+		}
+		for _, c := range candidates(rel) {
+			if exists(fsys, c) && !isDir(fsys, c) {
+				return c, true
+			}
+		}
+		return "", false
+	}
+	for _, c := range candidates("node_modules/" + target) {
+		if exists(fsys, c) && !isDir(fsys, c) {
+			return c, true
+		}
+	}
+	if !isDir(fsys, "node_modules") && declared(tsPackageName(target)) {
+		return "", true
+	}
+	return "", false
+}
+
+// tsPackageName returns the package part of a module specifier such as
+// @tsconfig/node20/tsconfig.json.
+func tsPackageName(spec string) string {
+	parts := strings.SplitN(spec, "/", 3)
+	if strings.HasPrefix(spec, "@") && len(parts) >= 2 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// tsStrict reports whether cfg ends up with strict enabled: its own
+// setting wins, otherwise the last extends target that sets it.
+func tsStrict(fsys fs.FS, dir string, cfg tsconfig, declared func(string) bool, depth int) bool {
+	if cfg.CompilerOptions.Strict != nil {
+		return *cfg.CompilerOptions.Strict
+	}
+	if depth >= maxExtendsDepth {
+		return false
+	}
+	targets := tsExtends(cfg)
+	for i := len(targets) - 1; i >= 0; i-- {
+		file, ok := resolveTSExtends(fsys, dir, targets[i], declared)
+		if !ok {
+			continue
+		}
+		if file == "" {
+			// A declared but uninstalled base may well enable strict.
+			return true
+		}
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			continue
+		}
+		base, err := parseTSConfig(b)
+		if err != nil {
+			continue
+		}
+		if base.CompilerOptions.Strict != nil || len(tsExtends(base)) > 0 {
+			return tsStrict(fsys, path.Dir(file), base, declared, depth+1)
+		}
+	}
+	return false
+}
+
+// tsSourceFiles lists files tsc would compile, skipping dependency trees
+// and hidden directories.
+func tsSourceFiles(ctx context.Context, fsys fs.FS, allowJS bool) ([]string, error) {
+	names, err := listFiles(ctx, fsys)
+	if err != nil {
+		return nil, err
+	}
+	exts := []string{".ts", ".tsx", ".mts", ".cts"}
+	if allowJS {
+		exts = append(exts, ".js", ".jsx", ".mjs", ".cjs")
+	}
+	var files []string
+	for _, name := range names {
+		if tsExcludedDir(path.Dir(name)) {
+			continue
+		}
+		for _, e := range exts {
+			if strings.HasSuffix(name, e) {
+				files = append(files, name)
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+// tsExcludedDir reports whether dir is, or is inside, a dependency tree or
+// hidden directory, which tsc leaves out of wildcard includes.
+func tsExcludedDir(dir string) bool {
+	for _, d := range strings.Split(dir, "/") {
+		if d == "node_modules" || (d != "." && strings.HasPrefix(d, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// tsIncludeMatches reports whether an include pattern matches any source
+// file. Like tsc, a final segment without wildcards or an extension names
+// a directory and matches everything below it.
+func tsIncludeMatches(pattern string, sources []string) bool {
+	pattern = strings.TrimPrefix(path.Clean(pattern), "./")
+	last := path.Base(pattern)
+	switch {
+	case pattern == ".":
+		// The config's own directory.
+		pattern = "**/*"
+	case !strings.ContainsAny(last, "*?") && path.Ext(last) == "":
+		pattern += "/**/*"
+	}
+	segs := strings.Split(pattern, "/")
+	for _, s := range sources {
+		if gitignore.MatchSegments(segs, strings.Split(s, "/")) {
+			return true
+		}
+	}
+	return false
+}
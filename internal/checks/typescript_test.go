@@ -0,0 +1,226 @@
+package checks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/repo"
+)
+
+const tsPackageJSON = `{"devDependencies": {"typescript": "^5.6.0", "@tsconfig/node20": "^20.1.0"}}`
+
+func TestTypeScriptCheck_NoTypeScript_NoOp(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"no package.json":      {"tsconfig.json": "{"},
+		"no typescript dep":    {"package.json": `{"dependencies": {"react": "^18"}}`},
+		"invalid package.json": {"package.json": "{"},
+	} {
+		if fs := runCheck(t, TypeScriptCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected no findings, got %+v", name, fs)
+		}
+	}
+}
+
+func TestTypeScriptCheck_MissingTSConfig(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{"package.json": tsPackageJSON})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "tsconfig.json is missing") {
+		t.Fatalf("unexpected findings:\n%s", findingMessages(fs))
+	}
+}
+
+func TestTypeScriptCheck_ValidConfigsPass(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"jsonc": {
+			"package.json": tsPackageJSON,
+			"tsconfig.json": `{
+  // Shared settings.
+  "compilerOptions": {
+    "strict": true, /* keep this on */
+    "outDir": "dist",
+  },
+  "include": ["src", "types/**/*.d.ts",],
+  "files": ["./env.d.ts"],
+}`,
+			"src/lib/index.ts": "",
+			"types/a/b.d.ts":   "",
+			"env.d.ts":         "",
+		},
+		"strict from local extends": {
+			"package.json":          tsPackageJSON,
+			"tsconfig.json":         `{"extends": "./config/base", "include": ["src/**/*"]}`,
+			"config/base.json":      `{"extends": "../config/strict.json"}`,
+			"config/strict.json":    `{"compilerOptions": {"strict": true}}`,
+			"src/index.tsx":         "",
+			"src/styles/theme.scss": "",
+		},
+		"declared package without node_modules": {
+			"package.json":  tsPackageJSON,
+			"tsconfig.json": `{"extends": "@tsconfig/node20/tsconfig.json", "include": ["src"]}`,
+			"src/main.ts":   "",
+		},
+		"installed package": {
+			"package.json":  tsPackageJSON,
+			"tsconfig.json": `{"extends": ["./base.json", "@tsconfig/node20"]}`,
+			"base.json":     `{"compilerOptions": {"strict": false}}`,
+			"node_modules/@tsconfig/node20/tsconfig.json": `{"compilerOptions": {"strict": true}}`,
+		},
+		"config directory": {
+			"package.json":    tsPackageJSON,
+			"tsconfig.json":   `{"compilerOptions": {"strict": true}, "include": [".", "./"]}`,
+			"src/lib/util.ts": "",
+		},
+		"allowJs": {
+			"package.json":      tsPackageJSON,
+			"tsconfig.json":     `{"compilerOptions": {"strict": true, "allowJs": true}, "include": ["scripts/*.mjs"]}`,
+			"scripts/build.mjs": "",
+		},
+	} {
+		if fs := runCheck(t, TypeScriptCheck{}, files); len(fs) != 0 {
+			t.Errorf("%s: expected no findings, got:\n%s", name, findingMessages(fs))
+		}
+	}
+}
+
+func TestTypeScriptCheck_InvalidJSONC(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":  tsPackageJSON,
+		"tsconfig.json": "{\n  // comment\n  \"compilerOptions\": {\n    \"strict\": true\n    \"noEmit\": true\n  }\n}\n",
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "tsconfig.json does not parse") {
+		t.Fatalf("unexpected findings:\n%s", findingMessages(fs))
+	}
+	if fs[0].Line != 5 || fs[0].Column != 5 {
+		t.Fatalf("expected error at 5:5, got %d:%d", fs[0].Line, fs[0].Column)
+	}
+}
+
+func TestTypeScriptCheck_StrictDisabled(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":  tsPackageJSON,
+		"tsconfig.json": "{\n  \"extends\": \"./base.json\",\n  \"compilerOptions\": {\n    \"strict\": false\n  }\n}\n",
+		"base.json":     `{"compilerOptions": {"strict": true}}`,
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "compilerOptions.strict is not enabled") {
+		t.Fatalf("unexpected findings:\n%s", findingMessages(fs))
+	}
+	if fs[0].Line != 4 || fs[0].Column != 5 {
+		t.Fatalf("expected span at the strict key, got %d:%d", fs[0].Line, fs[0].Column)
+	}
+
+	fs = runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":  tsPackageJSON,
+		"tsconfig.json": `{"compilerOptions": {"target": "es2022"}}`,
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "compilerOptions.strict is not enabled") {
+		t.Fatalf("unexpected findings:\n%s", findingMessages(fs))
+	}
+}
+
+func TestTypeScriptCheck_UnresolvedExtends(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":   tsPackageJSON,
+		"tsconfig.json":  `{"extends": ["./missing.json", "@tsconfig/strictest", "../outside.json"]}`,
+		"node_modules/x": "",
+	})
+	got := findingMessages(fs)
+	if len(fs) != 3 {
+		t.Fatalf("expected 3 findings, got:\n%s", got)
+	}
+	for _, want := range []string{"extends target ./missing.json", "extends target @tsconfig/strictest", "extends target ../outside.json"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "strict is not enabled") {
+		t.Errorf("strict should not be reported while extends is unresolved:\n%s", got)
+	}
+
+	// Undeclared packages cannot be resolved even before install.
+	fs = runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":  tsPackageJSON,
+		"tsconfig.json": `{"extends": "@vue/tsconfig/tsconfig.json", "compilerOptions": {"strict": true}}`,
+	})
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "extends target @vue/tsconfig/tsconfig.json") {
+		t.Fatalf("unexpected findings:\n%s", findingMessages(fs))
+	}
+}
+
+func TestTypeScriptCheck_IncludeAndFilesMatchNothing(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json":                  tsPackageJSON,
+		"tsconfig.json":                 `{"compilerOptions": {"strict": true}, "include": ["src", "lib/**/*.ts", "scripts"], "files": ["global.d.ts"]}`,
+		"src/app.ts":                    "",
+		"lib/readme.md":                 "",
+		"scripts/build.js":              "",
+		"node_modules/pkg/lib/index.ts": "",
+	})
+	got := findingMessages(fs)
+	if len(fs) != 3 {
+		t.Fatalf("expected 3 findings, got:\n%s", got)
+	}
+	for _, want := range []string{"include pattern lib/**/*.ts", "include pattern scripts", "files entry global.d.ts"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestTypeScriptCheck_IgnoredSourcesDoNotMatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json":     tsPackageJSON,
+		"tsconfig.json":    `{"compilerOptions": {"strict": true}, "include": ["src", "generated"]}`,
+		".gitignore":       "generated/\n",
+		"src/app.ts":       "",
+		"generated/api.ts": "",
+	})
+	idx, err := repo.Build(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	fs, err := (TypeScriptCheck{}).Run(context.Background(), dir, Options{FS: idx})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(fs) != 1 || !strings.Contains(fs[0].Message, "include pattern generated") {
+		t.Fatalf("expected the ignored include to match nothing, got:\n%s", findingMessages(fs))
+	}
+}
+
+func TestStripJSONC_KeepsStringsAndOffsets(t *testing.T) {
+	in := `{"url": "http://x/*y*/", "a": [1, 2,], /* c */ "b": "//",}`
+	out := string(stripJSONC([]byte(in)))
+	if len(out) != len(in) {
+		t.Fatalf("length changed: %d != %d", len(out), len(in))
+	}
+	want := `{"url": "http://x/*y*/", "a": [1, 2 ],         "b": "//" }`
+	if out != want {
+		t.Fatalf("got  %s\nwant %s", out, want)
+	}
+}
+
+func TestTypeScriptCheck_SpansPointAtOwnEntry(t *testing.T) {
+	fs := runCheck(t, TypeScriptCheck{}, map[string]string{
+		"package.json": tsPackageJSON,
+		"tsconfig.json": "{\n" +
+			"  // \"src/missing.ts\" was renamed\n" +
+			"  \"compilerOptions\": {\"strict\": true},\n" +
+			"  \"include\": [\"src/**/*.ts\", \"src/missing.ts\"],\n" +
+			"  \"files\": [\"src/missing.ts\"]\n" +
+			"}\n",
+		"src/app.ts": "",
+	})
+	if len(fs) != 2 {
+		t.Fatalf("expected 2 findings, got:\n%s", findingMessages(fs))
+	}
+	for _, f := range fs {
+		want := 4
+		if strings.HasPrefix(f.Message, "files entry") {
+			want = 5
+		}
+		if f.Line != want {
+			t.Errorf("%q: expected line %d, got %d:%d", f.Message, want, f.Line, f.Column)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+// Package config loads repository-level yardstick policy from a
+// .yardstick.yml or .yardstick.json file at the scan root.
+//
+// A config file lets teams commit policy next to their code instead of
+// repeating CLI flags in every pipeline:
+//
+//	format: json
+//	strict: true
+//	checks:
+//	  license:
+//	    level: error
+//	  changelog:
+//	    level: info
+//	  static_site:
+//	    enabled: false
+//	  readme_links:
+//	    timeout: 10s
+//	  readme:
+//	    settings:
+//	      sections: ["## Overview", "## Runbook", "## On-call"]
+//
+// Every key is validated, and unknown check keys fail fast the same way
+// unknown -only keys do, with the offending line in the error.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// FileNames lists the config file names discovered at the scan root.
+var FileNames = []string{".yardstick.yml", ".yardstick.yaml", ".yardstick.json"}
+
+// Error reports a problem at a specific line of a config file.
+type Error struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// Config is the decoded repository policy.
+type Config struct {
+	// Path is the file the config was loaded from.
+	Path string
+
+	// Format is the default -format value, empty when unset.
+	Format string
+
+	// Strict is the default -strict value, nil when unset.
+	Strict *bool
+
+	// Checks holds per-check policy keyed by check key.
+	Checks map[string]CheckConfig
+}
+
+// CheckConfig is the policy for a single check.
+type CheckConfig struct {
+	// Enabled turns a check on or off, nil keeps the default (enabled).
+	Enabled *bool
+
+	// Level overrides the severity of the check's findings, empty keeps the
+	// levels the check reports itself.
+	Level checks.Level
+
+	// Settings holds per-check parameters decoded against the check's Schema.
+	Settings checks.Settings
+
+	// Timeout bounds how long the check may run, zero keeps the -timeout
+	// default.
+	Timeout time.Duration
+}
+
+// Enabled reports whether the check should run. A nil Config enables all checks.
+func (c *Config) Enabled(key string) bool {
+	if c == nil {
+		return true
+	}
+	cc, ok := c.Checks[key]
+	return !ok || cc.Enabled == nil || *cc.Enabled
+}
+
+// Level returns the configured level override for a check, if any.
+func (c *Config) Level(key string) (checks.Level, bool) {
+	if c == nil {
+		return "", false
+	}
+	cc, ok := c.Checks[key]
+	return cc.Level, ok && cc.Level != ""
+}
+
+// Settings returns the configured settings for a check, nil when unset.
+func (c *Config) Settings(key string) checks.Settings {
+	if c == nil {
+		return nil
+	}
+	return c.Checks[key].Settings
+}
+
+// Timeout returns the configured timeout for a check, if any.
+func (c *Config) Timeout(key string) (time.Duration, bool) {
+	if c == nil {
+		return 0, false
+	}
+	cc, ok := c.Checks[key]
+	return cc.Timeout, ok && cc.Timeout > 0
+}
+
+// Discover returns the config file at root, or "" when there is none.
+// Having more than one candidate is an error so policy is never ambiguous.
+func Discover(root string) (string, error) {
+	name, err := DiscoverFS(os.DirFS(root), root)
+	if err != nil || name == "" {
+		return "", err
+	}
+	return filepath.Join(root, name), nil
+}
+
+// DiscoverFS is Discover for a scanned tree that may not live on disk, such
+// as an archive. It returns the config file's name within fsys; root only
+// names the tree in errors.
+func DiscoverFS(fsys fs.FS, root string) (string, error) {
+	var found []string
+	for _, name := range FileNames {
+		if st, err := fs.Stat(fsys, name); err == nil && !st.IsDir() {
+			found = append(found, name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", nil
+	case 1:
+		return found[0], nil
+	}
+	return "", fmt.Errorf("multiple config files found in %s: %s, keep only one", root, strings.Join(found, ", "))
+}
+
+// Load reads and validates the config file at path. all is the check registry
+// used to validate check keys, formats lists the accepted -format values.
+func Load(path string, all []checks.Check, formats []string) (*Config, error) {
+	// #nosec G304 -- config path is either user-provided or discovered at the scan root.
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, b, all, formats)
+}
+
+// Parse decodes config data. The file name selects the syntax: .json files
+// are parsed as JSON, anything else as YAML.
+func Parse(name string, data []byte, all []checks.Check, formats []string) (*Config, error) {
+	var root *Node
+	var err error
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		root, err = parseJSON(data)
+	} else {
+		root, err = parseYAML(data)
+	}
+	var cfg *Config
+	if err == nil {
+		d := decoder{available: make(map[string]checks.Check, len(all)), formats: formats}
+		for _, c := range all {
+			d.available[c.Key()] = c
+		}
+		cfg, err = d.decode(root)
+	}
+	if err != nil {
+		var ce *Error
+		if errors.As(err, &ce) {
+			ce.File = name
+		}
+		return nil, err
+	}
+	cfg.Path = name
+	return cfg, nil
+}
+
+type decoder struct {
+	available map[string]checks.Check
+	formats   []string
+}
+
+func (d *decoder) decode(root *Node) (*Config, error) {
+	cfg := &Config{Checks: map[string]CheckConfig{}}
+	if root.Kind == ScalarNode && root.Value == "" && !root.Quoted {
+		return cfg, nil
+	}
+	if root.Kind != MapNode {
+		return nil, errorf(root.Line, "config must be a mapping, got %s", root.describe())
+	}
+	for i, k := range root.Keys {
+		v := root.Items[i]
+		switch k.Value {
+		case "format":
+			s, err := v.String()
+			if err != nil {
+				return nil, errorf(v.Line, "format: %v", err)
+			}
+			if !slices.Contains(d.formats, s) {
+				return nil, errorf(v.Line, "invalid format %q, expected one of: %s", s, strings.Join(d.formats, ", "))
+			}
+			cfg.Format = s
+		case "strict":
+			b, err := v.Bool()
+			if err != nil {
+				return nil, errorf(v.Line, "strict: %v", err)
+			}
+			cfg.Strict = &b
+		case "checks":
+			if err := d.decodeChecks(v, cfg); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errorf(k.Line, "unknown key %q, expected one of: checks, format, strict", k.Value)
+		}
+	}
+	return cfg, nil
+}
+
+func (d *decoder) decodeChecks(n *Node, cfg *Config) error {
+	if n.Kind == ScalarNode && n.Value == "" && !n.Quoted {
+		return nil
+	}
+	if n.Kind != MapNode {
+		return errorf(n.Line, "checks must be a mapping of check key to settings, got %s", n.describe())
+	}
+	for i, k := range n.Keys {
+		if _, ok := d.available[k.Value]; !ok {
+			return errorf(k.Line, "unknown check key %q (run yardstick -list to see available checks)", k.Value)
+		}
+		cc, err := d.decodeCheck(k.Value, n.Items[i])
+		if err != nil {
+			return err
+		}
+		cfg.Checks[k.Value] = cc
+	}
+	return nil
+}
+
+func (d *decoder) decodeCheck(key string, n *Node) (CheckConfig, error) {
+	var cc CheckConfig
+	if n.Kind == ScalarNode && n.Value == "" && !n.Quoted {
+		return cc, nil
+	}
+	if n.Kind != MapNode {
+		return cc, errorf(n.Line, "checks.%s must be a mapping, got %s", key, n.describe())
+	}
+	for i, k := range n.Keys {
+		v := n.Items[i]
+		switch k.Value {
+		case "enabled":
+			b, err := v.Bool()
+			if err != nil {
+				return cc, errorf(v.Line, "checks.%s.enabled: %v", key, err)
+			}
+			cc.Enabled = &b
+		case "level":
+			s, err := v.String()
+			if err != nil {
+				return cc, errorf(v.Line, "checks.%s.level: %v", key, err)
+			}
+			switch lvl := checks.Level(s); lvl {
+			case checks.LevelInfo, checks.LevelWarn, checks.LevelError:
+				cc.Level = lvl
+			default:
+				return cc, errorf(v.Line, "checks.%s.level: invalid level %q, expected info, warn, or error", key, s)
+			}
+		case "settings":
+			settings, err := d.decodeSettings(key, v)
+			if err != nil {
+				return cc, err
+			}
+			cc.Settings = settings
+		case "timeout":
+			d, err := v.Duration()
+			if err != nil {
+				return cc, errorf(v.Line, "checks.%s.timeout: %v", key, err)
+			}
+			cc.Timeout = d
+		default:
+			return cc, errorf(k.Line, "unknown key %q in checks.%s, expected one of: enabled, level, settings, timeout", k.Value, key)
+		}
+	}
+	return cc, nil
+}
+
+// decodeSettings validates a settings block against the schema the check
+// declares and converts each value to the declared kind.
+func (d *decoder) decodeSettings(key string, n *Node) (checks.Settings, error) {
+	configurable, ok := d.available[key].(checks.Configurable)
+	if !ok {
+		return nil, errorf(n.Line, "check %q has no settings", key)
+	}
+	if n.Kind != MapNode {
+		return nil, errorf(n.Line, "checks.%s.settings must be a mapping, got %s", key, n.describe())
+	}
+	schema := configurable.Schema()
+	out := make(checks.Settings, len(n.Keys))
+	for i, k := range n.Keys {
+		idx := slices.IndexFunc(schema, func(s checks.Setting) bool { return s.Name == k.Value })
+		if idx < 0 {
+			names := make([]string, 0, len(schema))
+			for _, s := range schema {
+				names = append(names, s.Name)
+			}
+			return nil, errorf(k.Line, "unknown setting %q for check %s, expected one of: %s", k.Value, key, strings.Join(names, ", "))
+		}
+		v := n.Items[i]
+		var val any
+		var err error
+		switch schema[idx].Kind {
+		case checks.SettingString:
+			val, err = v.String()
+		case checks.SettingStringList:
+			val, err = v.StringList()
+		case checks.SettingBool:
+			val, err = v.Bool()
+		default:
+			err = fmt.Errorf("unsupported setting kind %q", schema[idx].Kind)
+		}
+		if err != nil {
+			return nil, errorf(v.Line, "checks.%s.settings.%s: %v", key, k.Value, err)
+		}
+		out[k.Value] = val
+	}
+	return out, nil
+}
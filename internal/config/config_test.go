@@ -0,0 +1,280 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+var testFormats = []string{"table", "json"}
+
+func TestParse_YAMLChecksAndDefaults(t *testing.T) {
+	src := `# repository policy
+format: json
+strict: true
+checks:
+  license:
+    level: error   # promote
+  changelog:
+    level: "info"
+  static_site:
+    enabled: false
+  readme_links:
+    timeout: 1m30s
+`
+	cfg, err := Parse(".yardstick.yml", []byte(src), checks.All(), testFormats)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cfg.Format != "json" || cfg.Strict == nil || !*cfg.Strict {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if lvl, ok := cfg.Level("license"); !ok || lvl != checks.LevelError {
+		t.Fatalf("expected license level error, got %q %v", lvl, ok)
+	}
+	if lvl, ok := cfg.Level("changelog"); !ok || lvl != checks.LevelInfo {
+		t.Fatalf("expected changelog level info, got %q %v", lvl, ok)
+	}
+	if _, ok := cfg.Level("readme"); ok {
+		t.Fatalf("readme should have no level override")
+	}
+	if cfg.Enabled("static_site") {
+		t.Fatalf("static_site should be disabled")
+	}
+	if !cfg.Enabled("readme") {
+		t.Fatalf("unconfigured checks should stay enabled")
+	}
+	if d, ok := cfg.Timeout("readme_links"); !ok || d != 90*time.Second {
+		t.Fatalf("expected readme_links timeout 1m30s, got %v %v", d, ok)
+	}
+	if _, ok := cfg.Timeout("license"); ok {
+		t.Fatalf("license should have no timeout override")
+	}
+}
+
+func TestParse_JSONEquivalent(t *testing.T) {
+	src := `{
+  "format": "table",
+  "checks": {
+    "license": {"level": "error"},
+    "static_site": {"enabled": false}
+  }
+}`
+	cfg, err := Parse(".yardstick.json", []byte(src), checks.All(), testFormats)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cfg.Format != "table" || cfg.Strict != nil {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if lvl, _ := cfg.Level("license"); lvl != checks.LevelError {
+		t.Fatalf("expected license level error, got %q", lvl)
+	}
+	if cfg.Enabled("static_site") {
+		t.Fatalf("static_site should be disabled")
+	}
+}
+
+func TestParse_ErrorsCarryLineNumbers(t *testing.T) {
+	cases := []struct {
+		name string
+		file string
+		src  string
+		line int
+		want string
+	}{
+		{"unknown check yaml", ".yardstick.yml", "checks:\n  readme: {}\n  does-not-exist:\n    enabled: false\n", 3, `unknown check key "does-not-exist"`},
+		{"unknown check json", ".yardstick.json", "{\n  \"checks\": {\n    \"nope\": {}\n  }\n}\n", 3, `unknown check key "nope"`},
+		{"bad level", ".yardstick.yml", "checks:\n  license:\n    level: fatal\n", 3, `invalid level "fatal"`},
+		{"bad bool", ".yardstick.yml", "strict: yes\n", 1, "expected true or false"},
+		{"bad format", ".yardstick.yml", "\nformat: xml\n", 2, `invalid format "xml"`},
+		{"unknown top key", ".yardstick.yml", "format: json\nonly: readme\n", 2, `unknown key "only"`},
+		{"unknown check setting", ".yardstick.yml", "checks:\n  readme:\n    severity: error\n", 3, `unknown key "severity"`},
+		{"bad indentation", ".yardstick.yml", "checks:\n  readme:\n      enabled: true\n    level: warn\n", 4, "unexpected indentation"},
+		{"json syntax", ".yardstick.json", "{\n  \"format\": \"json\",\n  \"strict\": tru\n}\n", 3, "invalid character"},
+		{"bad timeout", ".yardstick.yml", "checks:\n  readme_links:\n    timeout: 30\n", 3, "expected a positive duration"},
+		{"negative timeout", ".yardstick.json", "{\"checks\": {\"readme\": {\"timeout\": \"-1s\"}}}", 1, "expected a positive duration"},
+		{"duplicate key", ".yardstick.yml", "format: json\nformat: table\n", 2, `duplicate key "format"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.file, []byte(tc.src), checks.All(), testFormats)
+			var ce *Error
+			if !errors.As(err, &ce) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if ce.Line != tc.line || ce.File != tc.file {
+				t.Fatalf("expected %s:%d, got %s:%d (%v)", tc.file, tc.line, ce.File, ce.Line, err)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse_CheckSettings(t *testing.T) {
+	src := `checks:
+  readme:
+    settings:
+      sections:
+        - "## Overview"
+        - "## Runbook"
+        - "## On-call"
+  static_site:
+    settings:
+      pages: content
+  codeowners:
+    settings:
+      paths: .github/CODEOWNERS
+`
+	cfg, err := Parse(".yardstick.yml", []byte(src), checks.All(), testFormats)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	sections := cfg.Settings("readme").StringList("sections", nil)
+	if len(sections) != 3 || sections[2] != "## On-call" {
+		t.Fatalf("unexpected readme sections: %#v", sections)
+	}
+	if got := cfg.Settings("static_site").String("pages", "pages"); got != "content" {
+		t.Fatalf("unexpected static_site pages: %q", got)
+	}
+	if got := cfg.Settings("static_site").String("assets", "assets"); got != "assets" {
+		t.Fatalf("unset settings should fall back to defaults, got %q", got)
+	}
+	if paths := cfg.Settings("codeowners").StringList("paths", nil); len(paths) != 1 || paths[0] != ".github/CODEOWNERS" {
+		t.Fatalf("scalar should decode as a one-element list, got %#v", paths)
+	}
+	if cfg.Settings("license") != nil {
+		t.Fatalf("unconfigured check should have nil settings")
+	}
+}
+
+func TestParse_CheckSettingsValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		line int
+		want string
+	}{
+		{"unknown setting", "checks:\n  readme:\n    settings:\n      headings: [a]\n", 4, `unknown setting "headings" for check readme, expected one of: sections`},
+		{"wrong kind", "checks:\n  static_site:\n    settings:\n      pages: [a, b]\n", 4, "expected a string, got list"},
+		{"empty list item", "checks:\n  readme:\n    settings:\n      sections:\n        - \"## Usage\"\n        - ## Overview\n", 5, "expected a list of strings, found empty value at line 6"},
+		{"not configurable", "checks:\n  license:\n    settings:\n      file: COPYING\n", 4, `check "license" has no settings`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(".yardstick.yml", []byte(tc.src), checks.All(), testFormats)
+			var ce *Error
+			if !errors.As(err, &ce) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if ce.Line != tc.line || !strings.Contains(ce.Msg, tc.want) {
+				t.Fatalf("expected line %d mentioning %q, got %v", tc.line, tc.want, err)
+			}
+		})
+	}
+}
+
+func TestParseYAML_CollectionsAndScalars(t *testing.T) {
+	src := `list:
+  - "## Overview"
+  - 'it''s quoted'
+  - plain value
+flow: [a, "b, c", {k: v}]
+nested:
+- name: first
+  tags: [x]
+- name: second
+url: https://example.com/a#frag # trailing comment
+empty:
+`
+	n, err := parseYAML([]byte(src))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	list, _ := n.Get("list")
+	if list.Kind != SeqNode || len(list.Items) != 3 {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+	if list.Items[0].Value != "## Overview" || list.Items[1].Value != "it's quoted" || list.Items[2].Value != "plain value" {
+		t.Fatalf("unexpected list values: %q %q %q", list.Items[0].Value, list.Items[1].Value, list.Items[2].Value)
+	}
+	flow, _ := n.Get("flow")
+	if flow.Kind != SeqNode || len(flow.Items) != 3 || flow.Items[1].Value != "b, c" || flow.Items[2].Kind != MapNode {
+		t.Fatalf("unexpected flow list: %+v", flow)
+	}
+	nested, _ := n.Get("nested")
+	if nested.Kind != SeqNode || len(nested.Items) != 2 {
+		t.Fatalf("unexpected nested list: %+v", nested)
+	}
+	if name, _ := nested.Items[1].Get("name"); name == nil || name.Value != "second" || name.Line != 9 {
+		t.Fatalf("unexpected nested item: %+v", nested.Items[1])
+	}
+	if url, _ := n.Get("url"); url.Value != "https://example.com/a#frag" {
+		t.Fatalf("unexpected url: %q", url.Value)
+	}
+	if empty, _ := n.Get("empty"); empty.Kind != ScalarNode || empty.Value != "" {
+		t.Fatalf("unexpected empty value: %+v", empty)
+	}
+}
+
+func TestParseYAML_RejectsUnsupportedFeatures(t *testing.T) {
+	for _, src := range []string{
+		"a: |\n  text\n",
+		"a: &anchor x\n",
+		"a: x\n---\nb: y\n",
+		"a:\n\tb: c\n",
+		"a: \"unterminated\n",
+	} {
+		if _, err := parseYAML([]byte(src)); err == nil {
+			t.Fatalf("expected error for %q", src)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	if p, err := Discover(dir); err != nil || p != "" {
+		t.Fatalf("expected no config, got %q %v", p, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".yardstick.yml"), []byte("strict: true\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if p, err := Discover(dir); err != nil || p != filepath.Join(dir, ".yardstick.yml") {
+		t.Fatalf("unexpected discovery: %q %v", p, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".yardstick.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := Discover(dir); err == nil {
+		t.Fatalf("expected error for ambiguous config files")
+	}
+}
+
+func TestDiscoverFS(t *testing.T) {
+	fsys := fstest.MapFS{".yardstick.json": {Data: []byte("{}")}}
+	if name, err := DiscoverFS(fsys, "project.tar.gz"); err != nil || name != ".yardstick.json" {
+		t.Fatalf("unexpected discovery: %q %v", name, err)
+	}
+	fsys[".yardstick.yaml"] = &fstest.MapFile{}
+	if _, err := DiscoverFS(fsys, "project.tar.gz"); err == nil || !strings.Contains(err.Error(), "project.tar.gz") {
+		t.Fatalf("expected ambiguity error naming the tree, got %v", err)
+	}
+}
+
+func TestNilConfigDefaults(t *testing.T) {
+	var cfg *Config
+	if !cfg.Enabled("readme") {
+		t.Fatalf("nil config should enable every check")
+	}
+	if _, ok := cfg.Level("readme"); ok {
+		t.Fatalf("nil config should not override levels")
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// parseJSON converts a JSON document into Nodes, tracking the line of every
+// key and value so JSON configs get the same diagnostics as YAML ones.
+func parseJSON(data []byte) (*Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &jsonParser{dec: dec, data: data}
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	line := p.line()
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errorf(line, "unexpected content after the top-level value")
+	}
+	return n, nil
+}
+
+type jsonParser struct {
+	dec  *json.Decoder
+	data []byte
+}
+
+// line reports the line of the next token by skipping the whitespace and
+// separators the decoder has not consumed yet.
+func (p *jsonParser) line() int {
+	off := p.dec.InputOffset()
+	for off < int64(len(p.data)) {
+		switch p.data[off] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			off++
+			continue
+		}
+		break
+	}
+	return lineAt(p.data, off)
+}
+
+func (p *jsonParser) token() (json.Token, error) {
+	tok, err := p.dec.Token()
+	if err == nil {
+		return tok, nil
+	}
+	var syn *json.SyntaxError
+	if errors.As(err, &syn) {
+		// Offset counts the offending byte, so step back onto it.
+		return nil, errorf(lineAt(p.data, max(syn.Offset-1, 0)), "%s", syn.Error())
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, errorf(lineAt(p.data, int64(len(p.data))), "unexpected end of JSON input")
+	}
+	return nil, errorf(p.line(), "%s", err.Error())
+}
+
+func (p *jsonParser) value() (*Node, error) {
+	line := p.line()
+	tok, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n := &Node{Kind: MapNode, Line: line}
+			for p.dec.More() {
+				keyLine := p.line()
+				kt, err := p.token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := kt.(string)
+				if _, dup := n.Get(key); dup {
+					return nil, errorf(keyLine, "duplicate key %q", key)
+				}
+				val, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.Keys = append(n.Keys, &Node{Kind: ScalarNode, Line: keyLine, Value: key, Quoted: true})
+				n.Items = append(n.Items, val)
+			}
+			if _, err := p.token(); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+		n := &Node{Kind: SeqNode, Line: line}
+		for p.dec.More() {
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.Items = append(n.Items, item)
+		}
+		if _, err := p.token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &Node{Kind: ScalarNode, Line: line, Value: t, Quoted: true}, nil
+	case json.Number:
+		return &Node{Kind: ScalarNode, Line: line, Value: t.String()}, nil
+	case bool:
+		return &Node{Kind: ScalarNode, Line: line, Value: strconv.FormatBool(t)}, nil
+	default:
+		return &Node{Kind: ScalarNode, Line: line}, nil
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind identifies the shape of a parsed configuration node.
+type Kind int
+
+// Node kinds produced by the YAML and JSON front ends.
+const (
+	ScalarNode Kind = iota
+	MapNode
+	SeqNode
+)
+
+// Node is a format-neutral view of a parsed config document. Both the YAML
+// subset parser and the JSON parser produce Nodes so that validation and
+// decoding only need to be written once, and both can report line numbers.
+type Node struct {
+	Kind Kind
+	Line int
+
+	// Value holds the raw text of a scalar. Quoted is true when the scalar was
+	// written as a quoted string, which disables bool/number interpretation.
+	Value  string
+	Quoted bool
+
+	// Keys and Items describe collections. For MapNode, Keys[i] is the scalar
+	// key for Items[i]; for SeqNode only Items is populated.
+	Keys  []*Node
+	Items []*Node
+}
+
+// Get returns the value stored under key in a mapping node.
+func (n *Node) Get(key string) (*Node, bool) {
+	if n == nil || n.Kind != MapNode {
+		return nil, false
+	}
+	for i, k := range n.Keys {
+		if k.Value == key {
+			return n.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+func (n *Node) describe() string {
+	switch n.Kind {
+	case MapNode:
+		return "mapping"
+	case SeqNode:
+		return "list"
+	default:
+		if n.Value == "" && !n.Quoted {
+			return "empty value"
+		}
+		return "scalar"
+	}
+}
+
+// String decodes a scalar node as a string.
+func (n *Node) String() (string, error) {
+	if n.Kind != ScalarNode {
+		return "", fmt.Errorf("expected a string, got %s", n.describe())
+	}
+	return n.Value, nil
+}
+
+// Bool decodes a scalar node as a boolean.
+func (n *Node) Bool() (bool, error) {
+	if n.Kind == ScalarNode && !n.Quoted {
+		switch n.Value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("expected true or false, got %s", n.quotedValue())
+}
+
+// Duration decodes a scalar such as "30s" or "2m" as a positive duration.
+func (n *Node) Duration() (time.Duration, error) {
+	if n.Kind == ScalarNode {
+		if d, err := time.ParseDuration(n.Value); err == nil && d > 0 {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("expected a positive duration such as 30s, got %s", n.quotedValue())
+}
+
+// StringList decodes a sequence of scalars. A single scalar is accepted as a
+// one-element list to keep simple configs terse.
+func (n *Node) StringList() ([]string, error) {
+	switch n.Kind {
+	case SeqNode:
+		out := make([]string, 0, len(n.Items))
+		for _, it := range n.Items {
+			if it.Kind != ScalarNode || (it.Value == "" && !it.Quoted) {
+				return nil, fmt.Errorf("expected a list of strings, found %s at line %d", it.describe(), it.Line)
+			}
+			out = append(out, it.Value)
+		}
+		return out, nil
+	case ScalarNode:
+		if n.Value != "" || n.Quoted {
+			return []string{n.Value}, nil
+		}
+	}
+	return nil, fmt.Errorf("expected a list of strings, got %s", n.describe())
+}
+
+func (n *Node) quotedValue() string {
+	if n.Kind != ScalarNode || (n.Value == "" && !n.Quoted) {
+		return n.describe()
+	}
+	return strconv.Quote(n.Value)
+}
+
+// lineAt returns the 1-based line number for a byte offset in data.
+func lineAt(data []byte, off int64) int {
+	if off > int64(len(data)) {
+		off = int64(len(data))
+	}
+	return strings.Count(string(data[:off]), "\n") + 1
+}
@@ -0,0 +1,475 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseYAML parses the YAML subset yardstick config files need: block
+// mappings and sequences, flow lists and maps, plain and quoted scalars, and
+// comments. Anchors, aliases, tags, block scalars, and multi-document streams
+// are rejected with a line-numbered error rather than being silently misread.
+func parseYAML(data []byte) (*Node, error) {
+	lines, err := splitYAMLLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return &Node{Kind: MapNode, Line: 1}, nil
+	}
+	p := &yamlParser{lines: lines}
+	if lines[0].indent != 0 {
+		return nil, errorf(lines[0].num, "unexpected indentation")
+	}
+	n, err := p.parseBlock(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, errorf(p.lines[p.pos].num, "unexpected content %q", p.lines[p.pos].text)
+	}
+	return n, nil
+}
+
+// yamlLine is one significant source line with indentation and comments removed.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func splitYAMLLines(src string) ([]yamlLine, error) {
+	var out []yamlLine
+	seenContent := false
+	for i, raw := range strings.Split(src, "\n") {
+		num := i + 1
+		raw = strings.TrimSuffix(raw, "\r")
+		indent := 0
+		for indent < len(raw) && raw[indent] == ' ' {
+			indent++
+		}
+		if indent < len(raw) && raw[indent] == '\t' {
+			if strings.TrimSpace(raw) != "" && !strings.HasPrefix(strings.TrimSpace(raw), "#") {
+				return nil, errorf(num, "tab characters are not allowed for indentation")
+			}
+		}
+		text := strings.TrimRight(stripYAMLComment(raw[indent:]), " \t")
+		if text == "" {
+			continue
+		}
+		if indent == 0 && (text == "---" || text == "...") {
+			if seenContent {
+				return nil, errorf(num, "multiple YAML documents are not supported")
+			}
+			continue
+		}
+		if indent == 0 && strings.HasPrefix(text, "%") {
+			return nil, errorf(num, "YAML directives are not supported")
+		}
+		seenContent = true
+		out = append(out, yamlLine{num: num, indent: indent, text: text})
+	}
+	return out, nil
+}
+
+// stripYAMLComment removes a trailing comment. A '#' starts a comment only at
+// the beginning of the text or after whitespace, and never inside quotes.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"':
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quote = 0
+			}
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" \t[{,:-", s[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#':
+			if i == 0 || s[i-1] == ' ' || s[i-1] == '\t' {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseBlock(indent int) (*Node, error) {
+	if isSeqItem(p.lines[p.pos].text) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseMap(indent int) (*Node, error) {
+	n := &Node{Kind: MapNode, Line: p.lines[p.pos].num}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, errorf(l.num, "unexpected indentation")
+		}
+		if isSeqItem(l.text) {
+			return nil, errorf(l.num, "expected key: value, found a list item")
+		}
+		colon := keyColon(l.text)
+		if colon < 0 {
+			return nil, errorf(l.num, "expected key: value, found %q", l.text)
+		}
+		key, err := parseKey(l.text[:colon], l.num)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := n.Get(key.Value); dup {
+			return nil, errorf(l.num, "duplicate key %q", key.Value)
+		}
+		p.pos++
+
+		rest := strings.TrimSpace(l.text[colon+1:])
+		var val *Node
+		if rest == "" {
+			if p.pos < len(p.lines) {
+				next := p.lines[p.pos]
+				switch {
+				case next.indent > indent:
+					val, err = p.parseBlock(next.indent)
+				case next.indent == indent && isSeqItem(next.text):
+					val, err = p.parseSeq(indent)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			if val == nil {
+				val = &Node{Kind: ScalarNode, Line: l.num}
+			}
+		} else {
+			if val, err = parseInline(rest, l.num); err != nil {
+				return nil, err
+			}
+		}
+		n.Keys = append(n.Keys, key)
+		n.Items = append(n.Items, val)
+	}
+	return n, nil
+}
+
+func (p *yamlParser) parseSeq(indent int) (*Node, error) {
+	n := &Node{Kind: SeqNode, Line: p.lines[p.pos].num}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, errorf(l.num, "unexpected indentation")
+		}
+		if !isSeqItem(l.text) {
+			break
+		}
+		rest := strings.TrimLeft(l.text[1:], " ")
+		var item *Node
+		var err error
+		switch {
+		case rest == "":
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				item, err = p.parseBlock(p.lines[p.pos].indent)
+			} else {
+				item = &Node{Kind: ScalarNode, Line: l.num}
+			}
+		case isSeqItem(rest) || keyColon(rest) >= 0:
+			// "- key: value" and "- - item" open a nested block whose
+			// indentation is the column of the text after the dash.
+			col := l.indent + len(l.text) - len(rest)
+			p.lines[p.pos] = yamlLine{num: l.num, indent: col, text: rest}
+			item, err = p.parseBlock(col)
+		default:
+			p.pos++
+			item, err = parseInline(rest, l.num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.Items = append(n.Items, item)
+	}
+	return n, nil
+}
+
+// keyColon returns the index of the ':' that ends a mapping key, or -1 when
+// text is not a key/value pair.
+func keyColon(text string) int {
+	if text == "" {
+		return -1
+	}
+	switch text[0] {
+	case '"', '\'':
+		_, end, err := parseQuoted(text, 0)
+		if err != nil || end >= len(text) || text[end] != ':' {
+			return -1
+		}
+		if end+1 < len(text) && text[end+1] != ' ' && text[end+1] != '\t' {
+			return -1
+		}
+		return end
+	case '[', '{':
+		return -1
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseKey(text string, line int) (*Node, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errorf(line, "empty mapping key")
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		v, _, err := parseQuoted(text, line)
+		if err != nil {
+			return nil, err
+		}
+		return &Node{Kind: ScalarNode, Line: line, Value: v, Quoted: true}, nil
+	}
+	if strings.ContainsAny(text[:1], "?&*!|>[{") {
+		return nil, errorf(line, "unsupported mapping key %q", text)
+	}
+	return &Node{Kind: ScalarNode, Line: line, Value: text}, nil
+}
+
+// parseInline parses a value that appears on the same line as its key or dash.
+func parseInline(text string, line int) (*Node, error) {
+	switch text[0] {
+	case '[', '{':
+		f := &flowParser{s: text, line: line}
+		n, err := f.value(false)
+		if err != nil {
+			return nil, err
+		}
+		f.skipSpace()
+		if f.i < len(f.s) {
+			return nil, errorf(line, "unexpected text after flow collection: %q", f.s[f.i:])
+		}
+		return n, nil
+	case '"', '\'':
+		v, end, err := parseQuoted(text, line)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(text[end:]) != "" {
+			return nil, errorf(line, "unexpected text after quoted string: %q", text[end:])
+		}
+		return &Node{Kind: ScalarNode, Line: line, Value: v, Quoted: true}, nil
+	case '|', '>':
+		return nil, errorf(line, "block scalars are not supported, use a quoted string")
+	case '&', '*':
+		return nil, errorf(line, "anchors and aliases are not supported")
+	case '!':
+		return nil, errorf(line, "tags are not supported")
+	}
+	return plainScalar(text, line), nil
+}
+
+func plainScalar(text string, line int) *Node {
+	text = strings.TrimSpace(text)
+	if text == "~" || text == "null" {
+		text = ""
+	}
+	return &Node{Kind: ScalarNode, Line: line, Value: text}
+}
+
+// parseQuoted decodes the quoted scalar at the start of s and returns the
+// value and the index just past the closing quote.
+func parseQuoted(s string, line int) (string, int, error) {
+	q := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if q == '\'' {
+			if c == '\'' {
+				if i+1 < len(s) && s[i+1] == '\'' {
+					b.WriteByte('\'')
+					i++
+					continue
+				}
+				return b.String(), i + 1, nil
+			}
+			b.WriteByte(c)
+			continue
+		}
+		switch c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, errorf(line, "unterminated escape sequence")
+			}
+			i++
+			switch e := s[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '0':
+				b.WriteByte(0)
+			case '"', '\\', '/', ' ':
+				b.WriteByte(e)
+			case 'x', 'u', 'U':
+				width := map[byte]int{'x': 2, 'u': 4, 'U': 8}[e]
+				if i+width >= len(s) {
+					return "", 0, errorf(line, "invalid \\%c escape", e)
+				}
+				v, err := strconv.ParseUint(s[i+1:i+1+width], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(v)) {
+					return "", 0, errorf(line, "invalid \\%c escape", e)
+				}
+				b.WriteRune(rune(v))
+				i += width
+			default:
+				return "", 0, errorf(line, "unknown escape sequence \\%c", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, errorf(line, "unterminated quoted string")
+}
+
+// flowParser handles [a, b] and {k: v} collections on a single line.
+type flowParser struct {
+	s    string
+	i    int
+	line int
+}
+
+func (f *flowParser) skipSpace() {
+	for f.i < len(f.s) && (f.s[f.i] == ' ' || f.s[f.i] == '\t') {
+		f.i++
+	}
+}
+
+func (f *flowParser) value(isKey bool) (*Node, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, errorf(f.line, "unterminated flow collection")
+	}
+	switch c := f.s[f.i]; c {
+	case '[':
+		f.i++
+		n := &Node{Kind: SeqNode, Line: f.line}
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == ']' {
+				f.i++
+				return n, nil
+			}
+			item, err := f.value(false)
+			if err != nil {
+				return nil, err
+			}
+			n.Items = append(n.Items, item)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.i++
+		n := &Node{Kind: MapNode, Line: f.line}
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == '}' {
+				f.i++
+				return n, nil
+			}
+			key, err := f.value(true)
+			if err != nil {
+				return nil, err
+			}
+			if key.Kind != ScalarNode || key.Value == "" {
+				return nil, errorf(f.line, "invalid key in flow mapping")
+			}
+			if _, dup := n.Get(key.Value); dup {
+				return nil, errorf(f.line, "duplicate key %q", key.Value)
+			}
+			f.skipSpace()
+			if f.i >= len(f.s) || f.s[f.i] != ':' {
+				return nil, errorf(f.line, "expected ':' after key %q in flow mapping", key.Value)
+			}
+			f.i++
+			val, err := f.value(false)
+			if err != nil {
+				return nil, err
+			}
+			n.Keys = append(n.Keys, key)
+			n.Items = append(n.Items, val)
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	case '"', '\'':
+		v, end, err := parseQuoted(f.s[f.i:], f.line)
+		if err != nil {
+			return nil, err
+		}
+		f.i += end
+		return &Node{Kind: ScalarNode, Line: f.line, Value: v, Quoted: true}, nil
+	default:
+		stops := ",]}"
+		if isKey {
+			stops += ":"
+		}
+		start := f.i
+		for f.i < len(f.s) && strings.IndexByte(stops, f.s[f.i]) < 0 {
+			f.i++
+		}
+		return plainScalar(f.s[start:f.i], f.line), nil
+	}
+}
+
+// separator consumes a ',' between flow items, or leaves the closing
+// delimiter for the caller.
+func (f *flowParser) separator(closing byte) error {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return errorf(f.line, "unterminated flow collection, expected %q", closing)
+	}
+	switch f.s[f.i] {
+	case ',':
+		f.i++
+		return nil
+	case closing:
+		return nil
+	}
+	return errorf(f.line, "expected ',' or %q in flow collection, found %q", closing, f.s[f.i])
+}
+
+func errorf(line int, format string, args ...any) *Error {
+	return &Error{Line: line, Msg: fmt.Sprintf(format, args...)}
+}
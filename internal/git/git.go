@@ -0,0 +1,387 @@
+// Package git reads commits and trees straight from a repository's object
+// store, loose objects and packfiles alike, so yardstick can scan any
+// revision without a checkout, network access, or the git binary.
+//
+// Only what scanning needs is supported: SHA-1 repositories, pack index
+// version 2, refs (loose and packed), and revisions of the form
+// <ref-or-hash>[~N|^N]...:
+//
+//	r, err := git.Open(root)
+//	defer r.Close()
+//	commit, err := r.Resolve("origin/main")
+//	tree, err := r.TreeFS(commit)
+//	data, err := fs.ReadFile(tree, "README.md")
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Hash is a SHA-1 object name.
+type Hash [20]byte
+
+// String returns the full lowercase hex form of h.
+func (h Hash) String() string { return hex.EncodeToString(h[:]) }
+
+// parseHash decodes a full 40-character hex object name.
+func parseHash(s string) (Hash, bool) {
+	var h Hash
+	if len(s) != 2*len(h) {
+		return h, false
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return h, false
+	}
+	return h, true
+}
+
+// objectType is the kind of a stored object.
+type objectType int
+
+const (
+	typeCommit objectType = 1
+	typeTree   objectType = 2
+	typeBlob   objectType = 3
+	typeTag    objectType = 4
+	// Pack-only delta encodings, resolved before objects leave this package.
+	typeOfsDelta objectType = 6
+	typeRefDelta objectType = 7
+)
+
+func (t objectType) String() string {
+	switch t {
+	case typeCommit:
+		return "commit"
+	case typeTree:
+		return "tree"
+	case typeBlob:
+		return "blob"
+	case typeTag:
+		return "tag"
+	}
+	return "object type " + strconv.Itoa(int(t))
+}
+
+func parseObjectType(s string) (objectType, bool) {
+	for _, t := range []objectType{typeCommit, typeTree, typeBlob, typeTag} {
+		if t.String() == s {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// ErrNotFound is returned when an object or revision does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Repository is a read-only handle on a git directory. It is safe for
+// concurrent use.
+type Repository struct {
+	// gitDir holds HEAD and other per-worktree refs; commonDir holds
+	// objects, refs/, and packed-refs. They differ only in linked
+	// worktrees.
+	gitDir    string
+	commonDir string
+	// objectDirs lists the object directories to search: the repository's
+	// own followed by any alternates.
+	objectDirs []string
+	packs      []*pack
+}
+
+// Open opens the repository at dir, which is either a working tree root
+// holding .git (a directory, or a file pointing at one as in linked
+// worktrees and submodules) or a bare repository.
+func Open(dir string) (*Repository, error) {
+	gitDir, err := Dir(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{gitDir: gitDir, commonDir: gitDir}
+	if b, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		r.commonDir = resolvePath(gitDir, strings.TrimSpace(string(b)))
+	}
+	if err := r.checkObjectFormat(); err != nil {
+		return nil, err
+	}
+
+	r.objectDirs = []string{filepath.Join(r.commonDir, "objects")}
+	// #nosec G304 -- alternates live inside the user-selected repository.
+	if b, err := os.ReadFile(filepath.Join(r.commonDir, "objects", "info", "alternates")); err == nil {
+		for line := range strings.Lines(string(b)) {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				r.objectDirs = append(r.objectDirs, resolvePath(r.objectDirs[0], line))
+			}
+		}
+	}
+	for _, d := range r.objectDirs {
+		idxs, _ := filepath.Glob(filepath.Join(d, "pack", "*.idx"))
+		for _, idx := range idxs {
+			p, err := openPack(idx)
+			if err != nil {
+				_ = r.Close()
+				return nil, err
+			}
+			r.packs = append(r.packs, p)
+		}
+	}
+	return r, nil
+}
+
+// Close releases the packfiles held open by r.
+func (r *Repository) Close() error {
+	var errs []error
+	for _, p := range r.packs {
+		errs = append(errs, p.close())
+	}
+	return errors.Join(errs...)
+}
+
+// Dir returns the git directory of the working tree or bare repository at
+// dir, following a .git file as in linked worktrees and submodules.
+func Dir(dir string) (string, error) {
+	dotGit := filepath.Join(dir, ".git")
+	st, err := os.Stat(dotGit)
+	switch {
+	case err == nil && st.IsDir():
+		return dotGit, nil
+	case err == nil:
+		// #nosec G304 -- .git is inside the user-selected scan root.
+		b, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+		if !ok {
+			return "", fmt.Errorf("%s: malformed gitdir file", dotGit)
+		}
+		return resolvePath(dir, strings.TrimSpace(target)), nil
+	}
+	if isDir(filepath.Join(dir, "objects")) && isDir(filepath.Join(dir, "refs")) {
+		return dir, nil
+	}
+	return "", fmt.Errorf("%s is not a git repository", dir)
+}
+
+// checkObjectFormat rejects SHA-256 repositories, whose object names this
+// package cannot represent.
+func (r *Repository) checkObjectFormat() error {
+	// #nosec G304 -- config is inside the user-selected repository.
+	b, err := os.ReadFile(filepath.Join(r.commonDir, "config"))
+	if err != nil {
+		return nil
+	}
+	for line := range strings.Lines(string(b)) {
+		k, v, ok := strings.Cut(line, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(k), "objectformat") && strings.TrimSpace(v) != "sha1" {
+			return fmt.Errorf("%s: unsupported object format %q", r.gitDir, strings.TrimSpace(v))
+		}
+	}
+	return nil
+}
+
+// readObject returns the type and contents of h, with deltas resolved.
+func (r *Repository) readObject(h Hash) (objectType, []byte, error) {
+	for _, p := range r.packs {
+		if off, ok := p.offset(h); ok {
+			return p.read(r, off)
+		}
+	}
+	for _, d := range r.objectDirs {
+		t, b, err := readLoose(d, h)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return t, b, err
+	}
+	return 0, nil, fmt.Errorf("object %s: %w", h, ErrNotFound)
+}
+
+// objectSize returns the size of h's contents without reading all of them.
+func (r *Repository) objectSize(h Hash) (int64, error) {
+	for _, p := range r.packs {
+		if off, ok := p.offset(h); ok {
+			return p.size(off)
+		}
+	}
+	for _, d := range r.objectDirs {
+		f, err := os.Open(loosePath(d, h))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = f.Close() }()
+		_, size, _, err := looseHeader(f, h)
+		return size, err
+	}
+	return 0, fmt.Errorf("object %s: %w", h, ErrNotFound)
+}
+
+// readTyped reads h and checks that it is a want.
+func (r *Repository) readTyped(h Hash, want objectType) ([]byte, error) {
+	t, b, err := r.readObject(h)
+	if err != nil {
+		return nil, err
+	}
+	if t != want {
+		return nil, fmt.Errorf("object %s is a %s, not a %s", h, t, want)
+	}
+	return b, nil
+}
+
+func loosePath(dir string, h Hash) string {
+	s := h.String()
+	return filepath.Join(dir, s[:2], s[2:])
+}
+
+func readLoose(dir string, h Hash) (objectType, []byte, error) {
+	// #nosec G304 -- object paths are derived from hashes inside the repository.
+	f, err := os.Open(loosePath(dir, h))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	t, size, body, err := looseHeader(f, h)
+	if err != nil {
+		return 0, nil, err
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("object %s: %w", h, err)
+	}
+	if int64(len(b)) != size {
+		return 0, nil, fmt.Errorf("object %s: size %d, header says %d", h, len(b), size)
+	}
+	return t, b, nil
+}
+
+// looseHeader inflates the "<type> <size>\x00" header of a loose object and
+// returns a reader positioned at its contents.
+func looseHeader(f io.Reader, h Hash) (objectType, int64, io.Reader, error) {
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("object %s: %w", h, err)
+	}
+	br := bufio.NewReader(zr)
+	hdr, err := br.ReadString(0)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("object %s: malformed header", h)
+	}
+	kind, sizeStr, ok := strings.Cut(strings.TrimSuffix(hdr, "\x00"), " ")
+	t, known := parseObjectType(kind)
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if !ok || !known || err != nil || size < 0 {
+		return 0, 0, nil, fmt.Errorf("object %s: malformed header %q", h, hdr)
+	}
+	return t, size, br, nil
+}
+
+// commit holds the fields of a commit object yardstick uses.
+type commit struct {
+	tree    Hash
+	parents []Hash
+	// time is the committer timestamp in Unix seconds.
+	time int64
+}
+
+func (r *Repository) readCommit(h Hash) (commit, error) {
+	b, err := r.readTyped(h, typeCommit)
+	if err != nil {
+		return commit{}, err
+	}
+	var c commit
+	hasTree := false
+	for line := range strings.Lines(string(headers(b))) {
+		key, val, _ := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+		switch key {
+		case "tree":
+			c.tree, hasTree = parseHash(val)
+		case "parent":
+			p, ok := parseHash(val)
+			if !ok {
+				return commit{}, fmt.Errorf("commit %s: malformed parent %q", h, val)
+			}
+			c.parents = append(c.parents, p)
+		case "committer":
+			// "Name <email> <unix-seconds> <tz>"
+			fields := strings.Fields(val)
+			if len(fields) >= 2 {
+				c.time, _ = strconv.ParseInt(fields[len(fields)-2], 10, 64)
+			}
+		}
+	}
+	if !hasTree {
+		return commit{}, fmt.Errorf("commit %s: missing tree", h)
+	}
+	return c, nil
+}
+
+// headers returns the header block of a commit or tag, before the blank
+// line that starts its message.
+func headers(b []byte) []byte {
+	if i := bytes.Index(b, []byte("\n\n")); i >= 0 {
+		return b[:i+1]
+	}
+	return b
+}
+
+func resolvePath(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, p)
+}
+
+func isDir(p string) bool {
+	st, err := os.Stat(p)
+	return err == nil && st.IsDir()
+}
+
+// syncCache is a size-bounded cache of resolved pack objects, keyed by
+// offset. Delta chains share bases, so caching them keeps walking a tree
+// from re-inflating the same objects.
+type syncCache struct {
+	mu    sync.Mutex
+	items map[int64]cached
+	bytes int
+}
+
+type cached struct {
+	t objectType
+	b []byte
+}
+
+// maxCacheBytes bounds each pack's cache. When it fills up the cache is
+// dropped wholesale, which is cheap and good enough for a single scan.
+const maxCacheBytes = 32 << 20
+
+func (c *syncCache) get(off int64) (cached, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[off]
+	return v, ok
+}
+
+func (c *syncCache) put(off int64, v cached) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil || c.bytes+len(v.b) > maxCacheBytes {
+		c.items = map[int64]cached{}
+		c.bytes = 0
+	}
+	c.items[off] = v
+	c.bytes += len(v.b)
+}
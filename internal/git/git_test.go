@@ -0,0 +1,271 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// newRepo creates a repository with the git binary, which the package
+// itself never needs.
+func newRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_AUTHOR_DATE=2024-01-02T03:04:05Z",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com", "GIT_COMMITTER_DATE=2024-01-02T03:04:05Z",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q", "-b", "main")
+	return dir, git
+}
+
+func write(t *testing.T, dir, name, body string) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// history makes two commits, tags the first, and returns their hashes. The
+// large file changes slightly between them so packing produces deltas.
+func history(t *testing.T, dir string, git func(...string) string) (string, string) {
+	t.Helper()
+	big := strings.Repeat("line of shared content\n", 2000)
+	write(t, dir, "README.md", "# v1\n")
+	write(t, dir, "docs/big.txt", big)
+	write(t, dir, "src/pkg/main.go", "package main\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	first := git("rev-parse", "HEAD")
+	git("tag", "-a", "v1.0", "-m", "release")
+
+	write(t, dir, "README.md", "# v2\n")
+	write(t, dir, "docs/big.txt", big+"appended\n")
+	if err := os.Remove(filepath.Join(dir, "src", "pkg", "main.go")); err != nil {
+		t.Fatal(err)
+	}
+	write(t, dir, "CHANGELOG.md", "changes\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "second")
+	return first, git("rev-parse", "HEAD")
+}
+
+func checkTrees(t *testing.T, r *Repository, first, second string) {
+	t.Helper()
+	for rev, want := range map[string]string{
+		"HEAD":            second,
+		"main":            second,
+		"refs/heads/main": second,
+		"v1.0":            first,
+		"HEAD~1":          first,
+		"main^":           first,
+		"HEAD~0":          second,
+		second[:10]:       second,
+		first:             first,
+	} {
+		h, err := r.Resolve(rev)
+		if err != nil || h.String() != want {
+			t.Fatalf("Resolve(%q) = %s, %v; want %s", rev, h, err, want)
+		}
+	}
+	if _, err := r.Resolve("HEAD~5"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected missing ancestor, got %v", err)
+	}
+	if _, err := r.Resolve("nope"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected unknown ref, got %v", err)
+	}
+
+	h, _ := r.Resolve("v1.0")
+	tree, err := r.TreeFS(h)
+	if err != nil {
+		t.Fatalf("tree: %v", err)
+	}
+	if err := fstest.TestFS(tree, "README.md", "docs/big.txt", "src/pkg/main.go"); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := fs.ReadFile(tree, "README.md"); string(b) != "# v1\n" {
+		t.Fatalf("README at v1.0 = %q", b)
+	}
+	if _, err := fs.Stat(tree, "CHANGELOG.md"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("CHANGELOG.md should not exist at v1.0: %v", err)
+	}
+
+	h, _ = r.Resolve("HEAD")
+	tree, err = r.TreeFS(h)
+	if err != nil {
+		t.Fatalf("tree: %v", err)
+	}
+	b, err := fs.ReadFile(tree, "docs/big.txt")
+	if err != nil || !bytes.HasSuffix(b, []byte("appended\n")) {
+		t.Fatalf("big.txt at HEAD: %d bytes, %v", len(b), err)
+	}
+	info, err := fs.Stat(tree, "docs/big.txt")
+	if err != nil || info.Size() != int64(len(b)) || info.ModTime().Unix() != 1704164645 {
+		t.Fatalf("stat big.txt: %v %v", info, err)
+	}
+	if _, err := fs.Stat(tree, "src"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("src should be gone at HEAD: %v", err)
+	}
+}
+
+func TestRepository_LooseObjects(t *testing.T) {
+	dir, git := newRepo(t)
+	first, second := history(t, dir, git)
+	r, err := Open(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if len(r.packs) != 0 {
+		t.Fatalf("expected only loose objects, got %d packs", len(r.packs))
+	}
+	checkTrees(t, r, first, second)
+}
+
+func TestRepository_PackedObjectsAndRefs(t *testing.T) {
+	dir, git := newRepo(t)
+	first, second := history(t, dir, git)
+	git("gc", "-q", "--aggressive", "--prune=now")
+	if n, _ := filepath.Glob(filepath.Join(dir, ".git", "objects", "??")); len(n) != 0 {
+		t.Fatalf("expected every object packed, found loose dirs %v", n)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "packed-refs")); err != nil {
+		t.Fatalf("expected packed refs: %v", err)
+	}
+	r, err := Open(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	checkTrees(t, r, first, second)
+}
+
+func TestRepository_RemoteTrackingAndWorktree(t *testing.T) {
+	dir, git := newRepo(t)
+	first, second := history(t, dir, git)
+	git("update-ref", "refs/remotes/origin/main", first)
+	git("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	wt := filepath.Join(t.TempDir(), "wt")
+	git("worktree", "add", "-q", "--detach", wt, first)
+
+	r, err := Open(wt)
+	if err != nil {
+		t.Fatalf("open worktree: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	for rev, want := range map[string]string{"HEAD": first, "main": second, "origin/main": first, "origin": first} {
+		if h, err := r.Resolve(rev); err != nil || h.String() != want {
+			t.Fatalf("Resolve(%q) = %s, %v; want %s", rev, h, err, want)
+		}
+	}
+}
+
+func TestRepository_BranchesNamedLikeGitDirFiles(t *testing.T) {
+	dir, git := newRepo(t)
+	first, second := history(t, dir, git)
+	for _, name := range []string{"config", "index", "description"} {
+		git("branch", name, first)
+	}
+	// An all-caps file under .git that is not a ref is skipped too.
+	write(t, dir, ".git/NOTES_MSG", "not a ref\n")
+	git("branch", "NOTES_MSG", second)
+
+	r, err := Open(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	for rev, want := range map[string]string{"config": first, "index": first, "description": first, "NOTES_MSG": second, "HEAD": second} {
+		if h, err := r.Resolve(rev); err != nil || h.String() != want {
+			t.Fatalf("Resolve(%q) = %s, %v; want %s", rev, h, err, want)
+		}
+	}
+}
+
+func TestOpen_NotARepository(t *testing.T) {
+	if _, err := Open(t.TempDir()); err == nil {
+		t.Fatal("expected error for a directory without .git")
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("hello, world")
+	// Copy "hello" (offset 0, size 5), insert "!", copy ", world".
+	delta := []byte{12, 13, 0x90, 5, 1, '!', 0x91, 5, 7}
+	got, err := applyDelta(base, delta)
+	if err != nil || string(got) != "hello!, world" {
+		t.Fatalf("applyDelta = %q, %v", got, err)
+	}
+	if _, err := applyDelta(base, []byte{12, 5, 0x91, 10, 5}); err == nil {
+		t.Fatal("expected out-of-range copy to fail")
+	}
+}
+
+func TestReadIndex(t *testing.T) {
+	for _, version := range []string{"2", "3", "4"} {
+		t.Run("v"+version, func(t *testing.T) {
+			dir, git := newRepo(t)
+			write(t, dir, "README.md", "# r\n")
+			write(t, dir, "src/app/main.go", "package main\n")
+			write(t, dir, "src/app/main_test.go", "package main\n")
+			write(t, dir, "src/lib.go", "package src\n")
+			git("add", "-A")
+			git("update-index", "--index-version", version)
+			if version == "3" {
+				// Intent-to-add entries use the extended flags of version 3.
+				write(t, dir, "new.txt", "")
+				git("add", "-N", "new.txt")
+			}
+
+			b, err := os.ReadFile(filepath.Join(dir, ".git", "index"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries, err := ReadIndex(b)
+			if err != nil {
+				t.Fatalf("read index: %v", err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Path)
+			}
+			want := []string{"README.md", "src/app/main.go", "src/app/main_test.go", "src/lib.go"}
+			if version == "3" {
+				want = []string{"README.md", "new.txt", "src/app/main.go", "src/app/main_test.go", "src/lib.go"}
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("paths = %v, want %v", got, want)
+			}
+			if entries[0].Mode != 0o100644 || entries[0].Hash.String() != git("rev-parse", ":README.md") {
+				t.Fatalf("unexpected entry: %+v", entries[0])
+			}
+		})
+	}
+	if _, err := ReadIndex([]byte("not an index at all, clearly")); err == nil {
+		t.Fatal("expected error for bad signature")
+	}
+}
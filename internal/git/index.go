@@ -0,0 +1,113 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- git's own index checksum, not a security boundary.
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// IndexEntry is a path recorded in the git index, the staging area that
+// defines which files are tracked.
+type IndexEntry struct {
+	Path string
+	// Mode is the git file mode, for example 0o100644, or 0o160000 for a
+	// submodule.
+	Mode uint32
+	Hash Hash
+}
+
+// ModeSubmodule is the IndexEntry mode of a submodule (gitlink).
+const ModeSubmodule = 0o160000
+
+// ReadIndex parses the contents of a .git/index file, versions 2 to 4.
+// Paths with merge conflicts appear once. Extensions are ignored.
+func ReadIndex(data []byte) ([]IndexEntry, error) {
+	if len(data) < 12+sha1.Size || !bytes.Equal(data[:4], []byte("DIRC")) {
+		return nil, errors.New("git index: bad signature")
+	}
+	body, sum := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	// An all-zero checksum means index.skipHash is set.
+	if got := sha1.Sum(body); !bytes.Equal(sum, got[:]) && !bytes.Equal(sum, make([]byte, sha1.Size)) {
+		return nil, errors.New("git index: checksum mismatch")
+	}
+	version := binary.BigEndian.Uint32(data[4:])
+	if version < 2 || version > 4 {
+		return nil, fmt.Errorf("git index: unsupported version %d", version)
+	}
+	n := binary.BigEndian.Uint32(data[8:])
+
+	const fixed = 62 // ctime, mtime, dev, ino, mode, uid, gid, size, hash, flags
+	b := body[12:]
+	out := make([]IndexEntry, 0, n)
+	prev := ""
+	for i := uint32(0); i < n; i++ {
+		if len(b) < fixed {
+			return nil, errors.New("git index: truncated entry")
+		}
+		e := IndexEntry{Mode: binary.BigEndian.Uint32(b[24:])}
+		copy(e.Hash[:], b[40:60])
+		flags := binary.BigEndian.Uint16(b[60:])
+		off := fixed
+		if flags&0x4000 != 0 && version >= 3 {
+			off += 2
+		}
+		if len(b) < off {
+			return nil, errors.New("git index: truncated entry")
+		}
+
+		if version == 4 {
+			// The name is the previous one minus a varint count of trailing
+			// bytes, plus a NUL-terminated suffix; entries are not padded.
+			strip, m := indexVarint(b[off:])
+			if m == 0 || strip > uint64(len(prev)) {
+				return nil, errors.New("git index: malformed path prefix")
+			}
+			off += m
+			end := bytes.IndexByte(b[off:], 0)
+			if end < 0 {
+				return nil, errors.New("git index: unterminated path")
+			}
+			e.Path = prev[:len(prev)-int(strip)] + string(b[off:off+end])
+			b = b[off+end+1:]
+		} else {
+			end := bytes.IndexByte(b[off:], 0)
+			if end < 0 {
+				return nil, errors.New("git index: unterminated path")
+			}
+			e.Path = string(b[off : off+end])
+			// Entries are NUL-padded to a multiple of eight bytes.
+			size := (off + end + 8) &^ 7
+			if len(b) < size {
+				return nil, errors.New("git index: truncated entry")
+			}
+			b = b[size:]
+		}
+		// Conflicted paths have one entry per stage, adjacent in the index.
+		if e.Path != prev || len(out) == 0 {
+			out = append(out, e)
+		}
+		prev = e.Path
+	}
+	return out, nil
+}
+
+// indexVarint decodes the offset encoding shared with pack OFS_DELTA
+// entries. It returns the value and the number of bytes read, 0 on error.
+func indexVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i, c := range b {
+		if i > 0 {
+			v++
+		}
+		v = v<<7 | uint64(c&0x7f)
+		if c&0x80 == 0 {
+			return v, i + 1
+		}
+		if i >= 8 {
+			break
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,356 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxDeltaDepth bounds delta chains so a corrupt pack cannot recurse
+// forever. git itself defaults to chains of at most 50.
+const maxDeltaDepth = 1000
+
+// pack is a packfile and its version 2 index.
+type pack struct {
+	path    string
+	f       *os.File
+	fanout  [256]uint32
+	hashes  []Hash
+	offsets []int64
+	cache   syncCache
+}
+
+// openPack loads the index at idxPath and opens the packfile beside it.
+func openPack(idxPath string) (*pack, error) {
+	// #nosec G304 -- pack indexes live inside the user-selected repository.
+	b, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+	p := &pack{path: strings.TrimSuffix(idxPath, ".idx") + ".pack"}
+	if err := p.parseIndex(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", idxPath, err)
+	}
+	// #nosec G304 -- the pack sits beside its index.
+	if p.f, err = os.Open(p.path); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *pack) close() error { return p.f.Close() }
+
+var errCorruptIndex = errors.New("corrupt pack index")
+
+// parseIndex decodes a version 2 pack index: magic and version, a 256-entry
+// fanout table, the sorted object names, their CRCs, 31-bit offsets, and a
+// table of 64-bit offsets for entries whose high bit is set.
+func (p *pack) parseIndex(b []byte) error {
+	if len(b) < 8+256*4 || !bytes.Equal(b[:4], []byte("\xfftOc")) {
+		return errors.New("unsupported pack index version 1")
+	}
+	if v := binary.BigEndian.Uint32(b[4:]); v != 2 {
+		return fmt.Errorf("unsupported pack index version %d", v)
+	}
+	b = b[8:]
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(b[i*4:])
+	}
+	b = b[256*4:]
+	n := int(p.fanout[255])
+	if len(b) < n*(20+4+4) {
+		return errCorruptIndex
+	}
+	p.hashes = make([]Hash, n)
+	for i := range p.hashes {
+		copy(p.hashes[i][:], b[i*20:])
+	}
+	small := b[n*(20+4):]
+	large := small[n*4:]
+	p.offsets = make([]int64, n)
+	for i := range p.offsets {
+		off := binary.BigEndian.Uint32(small[i*4:])
+		if off&0x80000000 == 0 {
+			p.offsets[i] = int64(off)
+			continue
+		}
+		j := int(off & 0x7fffffff)
+		if len(large) < (j+1)*8 {
+			return errCorruptIndex
+		}
+		big := binary.BigEndian.Uint64(large[j*8:])
+		if big > math.MaxInt64 {
+			return errCorruptIndex
+		}
+		p.offsets[i] = int64(big)
+	}
+	return nil
+}
+
+// offset returns the pack offset of h.
+func (p *pack) offset(h Hash) (int64, bool) {
+	lo, hi := p.bucket(h[0])
+	i := lo + sort.Search(hi-lo, func(i int) bool { return bytes.Compare(p.hashes[lo+i][:], h[:]) >= 0 })
+	if i < hi && p.hashes[i] == h {
+		return p.offsets[i], true
+	}
+	return 0, false
+}
+
+// bucket returns the index range of names starting with first.
+func (p *pack) bucket(first byte) (int, int) {
+	lo := 0
+	if first > 0 {
+		lo = int(p.fanout[first-1])
+	}
+	return lo, int(p.fanout[first])
+}
+
+// withPrefix returns the names in p that start with the hex prefix.
+func (p *pack) withPrefix(prefix string) []Hash {
+	var out []Hash
+	first, ok := prefixFirstByte(prefix)
+	if !ok {
+		return nil
+	}
+	lo, hi := p.bucket(first)
+	for _, h := range p.hashes[lo:hi] {
+		if strings.HasPrefix(h.String(), prefix) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// entryHeader is the decoded header of a pack entry.
+type entryHeader struct {
+	t    objectType
+	size int64
+	// base locates a delta's base: an offset in this pack for
+	// typeOfsDelta, a name for typeRefDelta.
+	baseOff  int64
+	baseHash Hash
+	// data is the offset of the zlib stream.
+	data int64
+}
+
+func (p *pack) header(off int64) (entryHeader, error) {
+	r := bufio.NewReader(io.NewSectionReader(p.f, off, math.MaxInt64-off))
+	n := int64(0)
+	next := func() (byte, error) {
+		n++
+		return r.ReadByte()
+	}
+
+	c, err := next()
+	if err != nil {
+		return entryHeader{}, p.corrupt(off, err)
+	}
+	h := entryHeader{t: objectType(c >> 4 & 7), size: int64(c & 0x0f)}
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = next(); err != nil || shift > 56 {
+			return entryHeader{}, p.corrupt(off, err)
+		}
+		h.size |= int64(c&0x7f) << shift
+	}
+
+	switch h.t {
+	case typeOfsDelta:
+		// Big-endian base-128 with an implicit +1 per continuation byte.
+		if c, err = next(); err != nil {
+			return entryHeader{}, p.corrupt(off, err)
+		}
+		rel := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = next(); err != nil || rel > math.MaxInt64>>7 {
+				return entryHeader{}, p.corrupt(off, err)
+			}
+			rel = (rel+1)<<7 | int64(c&0x7f)
+		}
+		if rel <= 0 || rel > off {
+			return entryHeader{}, p.corrupt(off, nil)
+		}
+		h.baseOff = off - rel
+	case typeRefDelta:
+		if _, err := io.ReadFull(r, h.baseHash[:]); err != nil {
+			return entryHeader{}, p.corrupt(off, err)
+		}
+		n += int64(len(h.baseHash))
+	case typeCommit, typeTree, typeBlob, typeTag:
+	default:
+		return entryHeader{}, p.corrupt(off, fmt.Errorf("unknown %s", h.t))
+	}
+	h.data = off + n
+	return h, nil
+}
+
+func (p *pack) corrupt(off int64, err error) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		err = errors.New("truncated or malformed entry")
+	}
+	return fmt.Errorf("%s at offset %d: %w", p.path, off, err)
+}
+
+// inflate returns the zlib stream at off, which decompresses to size bytes.
+func (p *pack) inflate(off, size int64) ([]byte, error) {
+	zr, err := zlib.NewReader(bufio.NewReader(io.NewSectionReader(p.f, off, math.MaxInt64-off)))
+	if err != nil {
+		return nil, p.corrupt(off, err)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(zr, b); err != nil {
+		return nil, p.corrupt(off, err)
+	}
+	return b, nil
+}
+
+// read returns the object at off with its delta chain applied.
+func (p *pack) read(r *Repository, off int64) (objectType, []byte, error) {
+	return p.readDepth(r, off, 0)
+}
+
+func (p *pack) readDepth(r *Repository, off int64, depth int) (objectType, []byte, error) {
+	if v, ok := p.cache.get(off); ok {
+		return v.t, v.b, nil
+	}
+	if depth > maxDeltaDepth {
+		return 0, nil, p.corrupt(off, errors.New("delta chain too deep"))
+	}
+	h, err := p.header(off)
+	if err != nil {
+		return 0, nil, err
+	}
+	data, err := p.inflate(h.data, h.size)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	t := h.t
+	var base []byte
+	switch h.t {
+	case typeOfsDelta:
+		t, base, err = p.readDepth(r, h.baseOff, depth+1)
+	case typeRefDelta:
+		t, base, err = r.readObject(h.baseHash)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if base != nil {
+		if data, err = applyDelta(base, data); err != nil {
+			return 0, nil, p.corrupt(off, err)
+		}
+	}
+	p.cache.put(off, cached{t, data})
+	return t, data, nil
+}
+
+// size returns the size of the object at off. For deltas that is the target
+// size recorded at the start of the delta, so the base is never read.
+func (p *pack) size(off int64) (int64, error) {
+	h, err := p.header(off)
+	if err != nil {
+		return 0, err
+	}
+	if h.t != typeOfsDelta && h.t != typeRefDelta {
+		return h.size, nil
+	}
+	zr, err := zlib.NewReader(bufio.NewReader(io.NewSectionReader(p.f, h.data, math.MaxInt64-h.data)))
+	if err != nil {
+		return 0, p.corrupt(off, err)
+	}
+	br := bufio.NewReader(zr)
+	if _, err := binary.ReadUvarint(br); err != nil {
+		return 0, p.corrupt(off, err)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil || size > math.MaxInt64 {
+		return 0, p.corrupt(off, err)
+	}
+	return int64(size), nil
+}
+
+// applyDelta rebuilds a target object from base and a git delta: the base
+// and target sizes as little-endian base-128 varints, followed by copy
+// instructions (high bit set, with flag bits selecting which offset and size
+// bytes follow) and insert instructions (the low 7 bits count the literal
+// bytes that follow).
+func applyDelta(base, delta []byte) ([]byte, error) {
+	br := bytes.NewReader(delta)
+	srcSize, err := binary.ReadUvarint(br)
+	if err != nil || srcSize != uint64(len(base)) {
+		return nil, errors.New("delta base size mismatch")
+	}
+	dstSize, err := binary.ReadUvarint(br)
+	if err != nil || dstSize > math.MaxInt32 {
+		return nil, errors.New("malformed delta target size")
+	}
+	out := make([]byte, 0, dstSize)
+	for br.Len() > 0 {
+		op, _ := br.ReadByte()
+		switch {
+		case op&0x80 != 0:
+			var off, n uint32
+			for i := range 4 {
+				if op&(1<<i) != 0 {
+					c, err := br.ReadByte()
+					if err != nil {
+						return nil, errors.New("truncated delta copy")
+					}
+					off |= uint32(c) << (8 * i)
+				}
+			}
+			for i := range 3 {
+				if op&(0x10<<i) != 0 {
+					c, err := br.ReadByte()
+					if err != nil {
+						return nil, errors.New("truncated delta copy")
+					}
+					n |= uint32(c) << (8 * i)
+				}
+			}
+			if n == 0 {
+				n = 0x10000
+			}
+			end := uint64(off) + uint64(n)
+			if end > uint64(len(base)) {
+				return nil, errors.New("delta copy out of range")
+			}
+			out = append(out, base[off:end]...)
+		case op != 0:
+			lit := make([]byte, op)
+			if _, err := io.ReadFull(br, lit); err != nil {
+				return nil, errors.New("truncated delta insert")
+			}
+			out = append(out, lit...)
+		default:
+			return nil, errors.New("reserved delta opcode")
+		}
+	}
+	if uint64(len(out)) != dstSize {
+		return nil, errors.New("delta target size mismatch")
+	}
+	return out, nil
+}
+
+// prefixFirstByte decodes the first byte named by a hex prefix of at least
+// two characters.
+func prefixFirstByte(prefix string) (byte, bool) {
+	if len(prefix) < 2 {
+		return 0, false
+	}
+	var b [1]byte
+	if _, err := hex.Decode(b[:], []byte(prefix[:2])); err != nil {
+		return 0, false
+	}
+	return b[0], true
+}
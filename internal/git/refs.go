@@ -0,0 +1,278 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// maxSymrefDepth bounds chains of symbolic refs, as git does.
+const maxSymrefDepth = 5
+
+// errMalformedRef reports a ref file that names neither an object nor
+// another ref.
+var errMalformedRef = errors.New("malformed contents")
+
+// Resolve returns the commit named by rev: a full or abbreviated object
+// name, or a ref such as HEAD, main, origin/main, v1.2.0, or refs/tags/v1,
+// optionally followed by ~N (Nth first-parent ancestor) and ^N (Nth parent)
+// suffixes. Annotated tags are peeled to the commit they point at.
+func (r *Repository) Resolve(rev string) (Hash, error) {
+	base, suffix := rev, ""
+	if i := strings.IndexAny(rev, "~^"); i >= 0 {
+		base, suffix = rev[:i], rev[i:]
+	}
+	if base == "" {
+		return Hash{}, fmt.Errorf("invalid revision %q", rev)
+	}
+
+	h, err := r.resolveBase(base)
+	if err != nil {
+		return Hash{}, fmt.Errorf("revision %q: %w", rev, err)
+	}
+	if h, err = r.peel(h); err != nil {
+		return Hash{}, fmt.Errorf("revision %q: %w", rev, err)
+	}
+	for suffix != "" {
+		op := suffix[0]
+		digits := 0
+		for 1+digits < len(suffix) && suffix[1+digits] >= '0' && suffix[1+digits] <= '9' {
+			digits++
+		}
+		n := 1
+		if digits > 0 {
+			if n, err = strconv.Atoi(suffix[1 : 1+digits]); err != nil {
+				return Hash{}, fmt.Errorf("invalid revision %q", rev)
+			}
+		}
+		suffix = suffix[1+digits:]
+		if op == '~' {
+			for range n {
+				if h, err = r.parent(h, 1); err != nil {
+					return Hash{}, fmt.Errorf("revision %q: %w", rev, err)
+				}
+			}
+		} else if n > 0 {
+			if h, err = r.parent(h, n); err != nil {
+				return Hash{}, fmt.Errorf("revision %q: %w", rev, err)
+			}
+		}
+	}
+	return h, nil
+}
+
+// resolveBase resolves an object name or ref name, following the same
+// lookup order as git rev-parse.
+func (r *Repository) resolveBase(name string) (Hash, error) {
+	if h, ok := parseHash(name); ok {
+		return h, nil
+	}
+	for i, ref := range []string{
+		name,
+		"refs/" + name,
+		"refs/tags/" + name,
+		"refs/heads/" + name,
+		"refs/remotes/" + name,
+		"refs/remotes/" + name + "/HEAD",
+	} {
+		if i == 0 && !pseudoRef(name) && !strings.HasPrefix(name, "refs/") {
+			// Like git, only look up HEAD-style names directly under the
+			// git dir, so a branch named config is not read from .git/config.
+			continue
+		}
+		h, err := r.readRef(ref, 0)
+		if err == nil {
+			return h, nil
+		}
+		if i == 0 && errors.Is(err, errMalformedRef) {
+			// Not a ref file after all; try the namespaced candidates.
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return Hash{}, err
+		}
+	}
+	if isHex(name) && len(name) >= 4 {
+		return r.expand(name)
+	}
+	return Hash{}, ErrNotFound
+}
+
+// readRef resolves a ref to the object it names, following symbolic refs.
+func (r *Repository) readRef(name string, depth int) (Hash, error) {
+	if depth > maxSymrefDepth {
+		return Hash{}, fmt.Errorf("ref %s: too many levels of symbolic refs", name)
+	}
+	if !validRefName(name) {
+		return Hash{}, ErrNotFound
+	}
+	// Pseudo-refs such as HEAD are per worktree; everything under refs/
+	// is shared.
+	dir := r.gitDir
+	if strings.HasPrefix(name, "refs/") {
+		dir = r.commonDir
+	}
+	// #nosec G304 -- ref names are validated to stay inside the git dir.
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) && !isDirErr(err) {
+			return Hash{}, err
+		}
+		return r.packedRef(name)
+	}
+	s := strings.TrimSpace(string(b))
+	if target, ok := strings.CutPrefix(s, "ref:"); ok {
+		return r.readRef(strings.TrimSpace(target), depth+1)
+	}
+	if h, ok := parseHash(s); ok {
+		return h, nil
+	}
+	return Hash{}, fmt.Errorf("ref %s: %w", name, errMalformedRef)
+}
+
+// packedRef looks name up in packed-refs.
+func (r *Repository) packedRef(name string) (Hash, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Hash{}, ErrNotFound
+	}
+	if err != nil {
+		return Hash{}, err
+	}
+	defer func() { _ = f.Close() }()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		// Skip the header and "^<peeled>" lines; peel resolves tags itself.
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		hash, ref, ok := strings.Cut(line, " ")
+		if ok && ref == name {
+			if h, ok := parseHash(hash); ok {
+				return h, nil
+			}
+			return Hash{}, fmt.Errorf("packed-refs: malformed entry for %s", name)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Hash{}, err
+	}
+	return Hash{}, ErrNotFound
+}
+
+// expand resolves an abbreviated object name. It is an error for the prefix
+// to match more than one object.
+func (r *Repository) expand(prefix string) (Hash, error) {
+	prefix = strings.ToLower(prefix)
+	found := map[Hash]bool{}
+	for _, p := range r.packs {
+		for _, h := range p.withPrefix(prefix) {
+			found[h] = true
+		}
+	}
+	for _, d := range r.objectDirs {
+		entries, _ := os.ReadDir(filepath.Join(d, prefix[:2]))
+		for _, e := range entries {
+			if h, ok := parseHash(prefix[:2] + e.Name()); ok && strings.HasPrefix(h.String(), prefix) {
+				found[h] = true
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Hash{}, ErrNotFound
+	case 1:
+		for h := range found {
+			return h, nil
+		}
+	}
+	return Hash{}, fmt.Errorf("short object name %s is ambiguous", prefix)
+}
+
+// peel follows annotated tags until it reaches a commit.
+func (r *Repository) peel(h Hash) (Hash, error) {
+	for range maxSymrefDepth {
+		t, b, err := r.readObject(h)
+		if err != nil {
+			return Hash{}, err
+		}
+		switch t {
+		case typeCommit:
+			return h, nil
+		case typeTag:
+			target, ok := strings.CutPrefix(string(headers(b)), "object ")
+			if !ok {
+				return Hash{}, fmt.Errorf("tag %s: missing object", h)
+			}
+			target, _, _ = strings.Cut(target, "\n")
+			next, ok := parseHash(target)
+			if !ok {
+				return Hash{}, fmt.Errorf("tag %s: malformed object %q", h, target)
+			}
+			h = next
+		default:
+			return Hash{}, fmt.Errorf("object %s is a %s, not a commit", h, t)
+		}
+	}
+	return Hash{}, fmt.Errorf("object %s: too many levels of tags", h)
+}
+
+// parent returns the nth parent of commit h, counting from 1.
+func (r *Repository) parent(h Hash, n int) (Hash, error) {
+	c, err := r.readCommit(h)
+	if err != nil {
+		return Hash{}, err
+	}
+	if n > len(c.parents) {
+		return Hash{}, fmt.Errorf("commit %s has no parent %d: %w", h, n, ErrNotFound)
+	}
+	return c.parents[n-1], nil
+}
+
+// validRefName rejects names that could escape the git directory.
+func validRefName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return false
+	}
+	for part := range strings.SplitSeq(name, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// pseudoRef reports whether name looks like HEAD, FETCH_HEAD, or
+// ORIG_HEAD: the only names git rev-parse reads directly from the git dir.
+func pseudoRef(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'A' || c > 'Z') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDirErr reports whether reading a ref failed because the path is a
+// directory, as when "heads" is looked up as refs/heads.
+func isDirErr(err error) bool {
+	return errors.Is(err, syscall.EISDIR)
+}
@@ -0,0 +1,260 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TreeFS returns the tree of commit as a read-only fs.FS. Every entry
+// carries the committer time as its modification time. Symlinks are not
+// followed: they report fs.ModeSymlink and read as their target path.
+// Submodules appear as empty directories.
+func (r *Repository) TreeFS(commit Hash) (fs.FS, error) {
+	c, err := r.readCommit(commit)
+	if err != nil {
+		return nil, err
+	}
+	return &treeFS{
+		r:       r,
+		root:    c.tree,
+		modTime: time.Unix(c.time, 0).UTC(),
+		trees:   map[Hash][]treeEntry{},
+	}, nil
+}
+
+type treeFS struct {
+	r       *Repository
+	root    Hash
+	modTime time.Time
+
+	mu    sync.Mutex
+	trees map[Hash][]treeEntry
+}
+
+type treeEntry struct {
+	name string
+	mode fs.FileMode
+	hash Hash
+	// submodule marks gitlinks, whose hash names a commit in another
+	// repository.
+	submodule bool
+}
+
+// readTree returns the entries of tree h sorted by name, as io/fs expects.
+func (t *treeFS) readTree(h Hash) ([]treeEntry, error) {
+	t.mu.Lock()
+	entries, ok := t.trees[h]
+	t.mu.Unlock()
+	if ok {
+		return entries, nil
+	}
+
+	b, err := t.r.readTyped(h, typeTree)
+	if err != nil {
+		return nil, err
+	}
+	// Each entry is "<octal mode> <name>\x00<20-byte hash>".
+	for len(b) > 0 {
+		sp := bytes.IndexByte(b, ' ')
+		nul := bytes.IndexByte(b, 0)
+		if sp < 0 || nul < sp || len(b) < nul+1+len(Hash{}) {
+			return nil, fmt.Errorf("tree %s: malformed entry", h)
+		}
+		e := treeEntry{name: string(b[sp+1 : nul])}
+		copy(e.hash[:], b[nul+1:])
+		switch string(b[:sp]) {
+		case "40000":
+			e.mode = fs.ModeDir | 0o755
+		case "100755":
+			e.mode = 0o755
+		case "120000":
+			e.mode = fs.ModeSymlink | 0o777
+		case "160000":
+			e.mode = fs.ModeDir | 0o755
+			e.submodule = true
+		default:
+			// 100644 and the legacy 100664 are plain files.
+			e.mode = 0o644
+		}
+		entries = append(entries, e)
+		b = b[nul+1+len(Hash{}):]
+	}
+	slices.SortFunc(entries, func(a, b treeEntry) int { return strings.Compare(a.name, b.name) })
+
+	t.mu.Lock()
+	t.trees[h] = entries
+	t.mu.Unlock()
+	return entries, nil
+}
+
+// lookup walks name from the root tree.
+func (t *treeFS) lookup(op, name string) (treeEntry, error) {
+	if !fs.ValidPath(name) {
+		return treeEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e := treeEntry{name: ".", mode: fs.ModeDir | 0o755, hash: t.root}
+	if name == "." {
+		return e, nil
+	}
+	for part := range strings.SplitSeq(name, "/") {
+		if !e.mode.IsDir() || e.submodule {
+			return treeEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		entries, err := t.readTree(e.hash)
+		if err != nil {
+			return treeEntry{}, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		i, ok := slices.BinarySearchFunc(entries, part, func(e treeEntry, name string) int { return strings.Compare(e.name, name) })
+		if !ok {
+			return treeEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		e = entries[i]
+	}
+	return e, nil
+}
+
+func (t *treeFS) info(e treeEntry) (fs.FileInfo, error) {
+	fi := &fileInfo{name: path.Base(e.name), mode: e.mode, modTime: t.modTime}
+	if !e.mode.IsDir() {
+		size, err := t.r.objectSize(e.hash)
+		if err != nil {
+			return nil, err
+		}
+		fi.size = size
+	}
+	return fi, nil
+}
+
+func (t *treeFS) Stat(name string) (fs.FileInfo, error) {
+	e, err := t.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := t.info(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+func (t *treeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := t.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	if e.submodule {
+		return []fs.DirEntry{}, nil
+	}
+	entries, err := t.readTree(e.hash)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	out := make([]fs.DirEntry, 0, len(entries))
+	for _, child := range entries {
+		info, err := t.info(child)
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+		}
+		out = append(out, fs.FileInfoToDirEntry(info))
+	}
+	return out, nil
+}
+
+func (t *treeFS) ReadFile(name string) ([]byte, error) {
+	e, err := t.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	b, err := t.r.readTyped(e.hash, typeBlob)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	// Pack reads may share a cached buffer; callers own what we return.
+	return bytes.Clone(b), nil
+}
+
+func (t *treeFS) Open(name string) (fs.File, error) {
+	e, err := t.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := t.info(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if e.mode.IsDir() {
+		entries, err := t.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &dirFile{info: info, entries: entries}, nil
+	}
+	b, err := t.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &blobFile{info: info, Reader: bytes.NewReader(b)}, nil
+}
+
+type fileInfo struct {
+	name    string
+	mode    fs.FileMode
+	size    int64
+	modTime time.Time
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() any           { return nil }
+
+type blobFile struct {
+	info fs.FileInfo
+	*bytes.Reader
+}
+
+func (f *blobFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *blobFile) Close() error               { return nil }
+
+type dirFile struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
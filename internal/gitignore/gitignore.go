@@ -0,0 +1,212 @@
+// Package gitignore matches paths against .gitignore files the way git
+// does: blank lines and # comments are skipped, ! negates, a trailing /
+// matches directories only, a pattern with a slash before its end is
+// anchored to the directory of its .gitignore, and ** spans directories.
+//
+// A Matcher reads .gitignore files lazily from an fs.FS, so nested files
+// are only loaded for directories that are actually looked at. Patterns in
+// .git/info/exclude apply at the root with the lowest precedence.
+package gitignore
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Pattern is one parsed .gitignore line.
+type Pattern struct {
+	// segs are the glob segments of the pattern. Unanchored patterns are
+	// stored with a leading "**" so they match at any depth.
+	segs    []string
+	negate  bool
+	dirOnly bool
+}
+
+// Parse returns the patterns in a .gitignore file, in file order.
+func Parse(data []byte) []Pattern {
+	var out []Pattern
+	for line := range strings.Lines(string(data)) {
+		if p, ok := parseLine(line); ok {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func parseLine(line string) (Pattern, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	// Trailing spaces are ignored unless escaped with a backslash.
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || line[0] == '#' {
+		return Pattern{}, false
+	}
+
+	var p Pattern
+	if line[0] == '!' {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return Pattern{}, false
+	}
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	// path.Match negates classes with ^, gitignore with !.
+	line = strings.ReplaceAll(line, "[!", "[^")
+	p.segs = strings.Split(line, "/")
+	if !anchored {
+		p.segs = append([]string{"**"}, p.segs...)
+	}
+	return p, true
+}
+
+// Match reports whether the pattern matches name, a slash-separated path
+// relative to the directory holding the pattern's .gitignore.
+func (p Pattern) Match(name string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	return MatchSegments(p.segs, strings.Split(name, "/"))
+}
+
+// Negated reports whether the pattern re-includes what it matches.
+func (p Pattern) Negated() bool { return p.negate }
+
+// MatchSegments reports whether the segments of a slash-separated name
+// match pattern segments, each a path.Match pattern or ** spanning zero or
+// more directories. A trailing ** matches only what is inside.
+func MatchSegments(pat, name []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			rest := pat[1:]
+			// A trailing /** matches everything inside, not the
+			// directory itself.
+			if len(rest) == 0 {
+				return len(name) > 0
+			}
+			for i := range len(name) + 1 {
+				if MatchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pat[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pat, name = pat[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// Matcher answers ignore queries for a tree. It is safe for concurrent use.
+type Matcher struct {
+	fsys fs.FS
+
+	mu  sync.Mutex
+	dir map[string][]Pattern
+}
+
+// New returns a Matcher reading .gitignore files from fsys.
+func New(fsys fs.FS) *Matcher {
+	return &Matcher{fsys: fsys, dir: map[string][]Pattern{}}
+}
+
+// patterns returns the patterns that apply within dir, lowest precedence
+// first.
+func (m *Matcher) patterns(dir string) []Pattern {
+	m.mu.Lock()
+	ps, ok := m.dir[dir]
+	m.mu.Unlock()
+	if ok {
+		return ps
+	}
+	if dir == "." {
+		if b, err := fs.ReadFile(m.fsys, ".git/info/exclude"); err == nil {
+			ps = Parse(b)
+		}
+	}
+	if b, err := fs.ReadFile(m.fsys, path.Join(dir, ".gitignore")); err == nil {
+		ps = append(ps, Parse(b)...)
+	}
+	m.mu.Lock()
+	m.dir[dir] = ps
+	m.mu.Unlock()
+	return ps
+}
+
+// Match reports whether name itself is ignored, without looking at its
+// parent directories. Walkers that never descend into ignored directories
+// can use it directly; everyone else wants Ignored.
+//
+// The .gitignore closest to name wins, and within a file the last matching
+// pattern wins, so a later !pattern re-includes what an earlier one
+// excluded.
+func (m *Matcher) Match(name string, isDir bool) bool {
+	dirs := []string{"."}
+	for i := range len(name) {
+		if name[i] == '/' {
+			dirs = append(dirs, name[:i])
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rel := name
+		if dirs[i] != "." {
+			rel = name[len(dirs[i])+1:]
+		}
+		if ignored, ok := lastMatch(m.patterns(dirs[i]), rel, isDir); ok {
+			return ignored
+		}
+	}
+	return false
+}
+
+// lastMatch applies the last pattern in ps that matches name. ok is false
+// when none does.
+func lastMatch(ps []Pattern, name string, isDir bool) (ignored, ok bool) {
+	for i := len(ps) - 1; i >= 0; i-- {
+		if ps[i].Match(name, isDir) {
+			return !ps[i].negate, true
+		}
+	}
+	return false, false
+}
+
+// Ignored reports whether the patterns of a single .gitignore ignore name,
+// a path relative to that file's directory, including through an ignored
+// parent directory. Use it to judge one file's contents in isolation from
+// the rest of the tree.
+func Ignored(ps []Pattern, name string, isDir bool) bool {
+	for i := range len(name) {
+		if name[i] == '/' {
+			if ignored, _ := lastMatch(ps, name[:i], true); ignored {
+				return true
+			}
+		}
+	}
+	ignored, _ := lastMatch(ps, name, isDir)
+	return ignored
+}
+
+// Ignored reports whether name is ignored, either itself or because a
+// parent directory is. As in git, a file cannot be re-included once its
+// directory is excluded.
+func (m *Matcher) Ignored(name string, isDir bool) bool {
+	for i := range len(name) {
+		if name[i] == '/' && m.Match(name[:i], true) {
+			return true
+		}
+	}
+	return m.Match(name, isDir)
+}
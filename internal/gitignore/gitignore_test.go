@@ -0,0 +1,115 @@
+package gitignore
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcher(t *testing.T) {
+	m := New(fstest.MapFS{
+		".git/info/exclude": {Data: []byte("local.txt\nscratch/\n")},
+		".gitignore": {Data: []byte(`# build output
+/dist
+build/
+*.log
+!keep.log
+node_modules/
+docs/**/draft.md
+secret\#1
+vendor/**
+trailing.txt   
+[!a]x.tmp
+`)},
+		"pkg/.gitignore": {Data: []byte("generated.go\n!local.txt\n/only-here\n")},
+	})
+
+	for _, tc := range []struct {
+		name  string
+		isDir bool
+		want  bool
+	}{
+		{"dist", true, true},
+		{"pkg/dist", true, false}, // anchored to the root
+		{"build", true, true},
+		{"build", false, false}, // directory-only
+		{"src/build", true, true},
+		{"app.log", false, true},
+		{"logs/deep/app.log", false, true},
+		{"keep.log", false, false}, // negated
+		{"node_modules/react/index.js", false, true},
+		{"docs/draft.md", false, true},
+		{"docs/a/b/draft.md", false, true},
+		{"draft.md", false, false},
+		{"secret#1", false, true},
+		{"vendor", true, false}, // /** matches contents only
+		{"vendor/x/y.go", false, true},
+		{"trailing.txt", false, true},
+		{"bx.tmp", false, true},
+		{"ax.tmp", false, false},
+		{"local.txt", false, true},
+		{"pkg/local.txt", false, false}, // nested file overrides info/exclude
+		{"scratch/notes", false, true},
+		{"pkg/generated.go", false, true},
+		{"generated.go", false, false},
+		{"pkg/only-here", false, true},
+		{"pkg/sub/only-here", false, false},
+		{"README.md", false, false},
+	} {
+		if got := m.Ignored(tc.name, tc.isDir); got != tc.want {
+			t.Errorf("Ignored(%q, %v) = %v, want %v", tc.name, tc.isDir, got, tc.want)
+		}
+	}
+}
+
+func TestIgnored_ParentExclusionWins(t *testing.T) {
+	m := New(fstest.MapFS{
+		".gitignore": {Data: []byte("dist/\n!dist/keep.txt\n")},
+	})
+	if m.Match("dist/keep.txt", false) {
+		t.Fatal("Match should only consider the path itself")
+	}
+	if !m.Ignored("dist/keep.txt", false) {
+		t.Fatal("a file cannot be re-included when its directory is excluded")
+	}
+}
+
+func TestParse_SkipsBlankAndComments(t *testing.T) {
+	ps := Parse([]byte("\n# comment\n   \n/\n!important\n"))
+	if len(ps) != 1 || !ps[0].Negated() || !ps[0].Match("a/important", false) {
+		t.Fatalf("unexpected patterns: %+v", ps)
+	}
+}
+
+func TestIgnored_SingleFile(t *testing.T) {
+	ps := Parse([]byte("build/\n*.pyc\n!keep.pyc\n"))
+	for name, want := range map[string]bool{
+		"build/out.bin": true,
+		"a/b/x.pyc":     true,
+		"keep.pyc":      false,
+		"main.go":       false,
+	} {
+		if got := Ignored(ps, name, false); got != want {
+			t.Errorf("Ignored(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"src/**/*.ts", "src/a.ts", true},
+		{"src/**/*.ts", "src/a/b/c.ts", true},
+		{"src/**/*.ts", "lib/a.ts", false},
+		{"**/*.d.ts", "types/x.d.ts", true},
+		{"src/**", "src/a/b", true},
+		{"src/**", "src", false},
+		{"src/*", "src/a/b", false},
+	} {
+		if got := MatchSegments(strings.Split(tc.pattern, "/"), strings.Split(tc.name, "/")); got != tc.want {
+			t.Errorf("MatchSegments(%q, %q) = %v, want %v", tc.pattern, tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hittegit/yardstick/internal/gitignore"
+)
+
+// Open indexes the tree at p: a directory, or a .zip, .tar.gz, or .tgz
+// archive. Archives whose entries all sit under one top-level directory, as
+// release tarballs usually do, are indexed from inside that directory so
+// checks see the project root.
+func Open(ctx context.Context, p string) (*Index, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return Build(ctx, p)
+	}
+
+	lower := strings.ToLower(p)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return openZip(ctx, p)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return openTarGz(ctx, p)
+	}
+	return nil, fmt.Errorf("%s is not a directory or a .zip, .tar.gz, or .tgz archive", p)
+}
+
+// openZip loads the archive into memory so the index owns no open file.
+func openZip(ctx context.Context, p string) (*Index, error) {
+	// #nosec G304 -- the archive is the user-selected scan target.
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p, err)
+	}
+	var fsys fs.FS = zr
+	if top, ok := singleTopDir(fsys); ok {
+		if fsys, err = fs.Sub(zr, top); err != nil {
+			return nil, err
+		}
+	}
+	return FromFS(ctx, fsys, p)
+}
+
+// singleTopDir returns the only entry at the root of fsys when it is a
+// directory.
+func singleTopDir(fsys fs.FS) (string, bool) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return "", false
+	}
+	return entries[0].Name(), true
+}
+
+// openTarGz reads every regular file of a gzip-compressed tarball into the
+// index. Links and special files are skipped; they cannot be resolved
+// without the filesystem the archive was made on.
+func openTarGz(ctx context.Context, p string) (*Index, error) {
+	// #nosec G304 -- the archive is the user-selected scan target.
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", p, err)
+	}
+
+	infos := map[string]fs.FileInfo{}
+	data := map[string][]byte{}
+	tr := tar.NewReader(zr)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", p, err)
+		}
+		name := path.Clean(strings.TrimPrefix(h.Name, "/"))
+		if name == "." || !fs.ValidPath(name) {
+			continue
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			infos[name] = h.FileInfo()
+		case tar.TypeReg:
+			b, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %s: %w", p, name, err)
+			}
+			infos[name] = h.FileInfo()
+			data[name] = b
+		}
+	}
+
+	// Tarballs may omit directory entries; synthesize the missing parents.
+	for name := range infos {
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			if _, ok := infos[dir]; !ok {
+				infos[dir] = dirInfo(path.Base(dir))
+			}
+		}
+	}
+	prefix := ""
+	if top, ok := singleTop(infos); ok {
+		prefix = top + "/"
+	}
+
+	idx := newIndex(p, dirInfo("."))
+	// Lexical order puts every directory before its contents, as add needs.
+	names := make([]string, 0, len(infos))
+	for name := range infos {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rel := strings.TrimPrefix(name, prefix)
+		info := infos[name]
+		idx.add(rel, info)
+		if info.Mode().IsRegular() {
+			idx.files = append(idx.files, rel)
+			idx.contents[rel] = data[name]
+		}
+	}
+	// Everything is already in memory, so ignored files are only dropped
+	// from the file list.
+	ign := gitignore.New(idx)
+	idx.files = slices.DeleteFunc(idx.files, func(f string) bool { return ign.Ignored(f, false) })
+	return idx, nil
+}
+
+// singleTop returns the only top-level name in infos when it is a directory.
+func singleTop(infos map[string]fs.FileInfo) (string, bool) {
+	top := ""
+	for name, info := range infos {
+		if strings.Contains(name, "/") {
+			continue
+		}
+		if top != "" || !info.IsDir() {
+			return "", false
+		}
+		top = name
+	}
+	return top, top != ""
+}
+
+// dirInfo describes a directory the archive implies but does not list.
+type dirInfo string
+
+func (d dirInfo) Name() string       { return string(d) }
+func (d dirInfo) Size() int64        { return 0 }
+func (d dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o755 }
+func (d dirInfo) ModTime() time.Time { return time.Time{} }
+func (d dirInfo) IsDir() bool        { return true }
+func (d dirInfo) Sys() any           { return nil }
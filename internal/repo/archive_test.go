@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func writeZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "project.zip")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func writeTarGz(t *testing.T, name string, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		h := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Links cannot be resolved inside an archive and are skipped.
+	if err := tw.WriteHeader(&tar.Header{Name: "project-1.0/link", Linkname: "README.md", Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []interface{ Close() error }{tw, gz, f} {
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+func TestOpen_TarGzStripsSingleTopDir(t *testing.T) {
+	p := writeTarGz(t, "project-1.0.tar.gz", map[string]string{
+		"project-1.0/README.md":          "# Title\n",
+		"project-1.0/.github/CODEOWNERS": "* @team\n",
+		"./project-1.0/docs/a/guide.md":  "# Guide\n",
+	})
+	idx, err := Open(context.Background(), p)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if idx.Root() != p || idx.OnDisk() {
+		t.Fatalf("Root() = %q, OnDisk() = %v, want %q and false", idx.Root(), idx.OnDisk(), p)
+	}
+	want := []string{".github/CODEOWNERS", "README.md", "docs/a/guide.md"}
+	if got := idx.Files(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Files() = %v, want %v", got, want)
+	}
+	if err := fstest.TestFS(idx, want...); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := fs.ReadFile(idx, "docs/a/guide.md"); err != nil || string(b) != "# Guide\n" {
+		t.Fatalf("read: %q %v", b, err)
+	}
+}
+
+func TestOpen_ZipKeepsRootWithSeveralTopEntries(t *testing.T) {
+	p := writeZip(t, map[string]string{
+		"README.md":   "# Title\n",
+		"src/main.go": "package main\n",
+	})
+	idx, err := Open(context.Background(), p)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := fstest.TestFS(idx, "README.md", "src/main.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	nested := writeZip(t, map[string]string{"pkg/go.mod": "module example\n"})
+	idx, err = Open(context.Background(), nested)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if b, err := fs.ReadFile(idx, "go.mod"); err != nil || string(b) != "module example\n" {
+		t.Fatalf("expected single top directory to be stripped: %q %v", b, err)
+	}
+}
+
+func TestOpen_RejectsUnsupportedFiles(t *testing.T) {
+	root := writeTree(t, map[string]string{"notes.txt": "", "bad.zip": "not a zip"})
+	for _, name := range []string{"notes.txt", "bad.zip"} {
+		if _, err := Open(context.Background(), filepath.Join(root, name)); err == nil {
+			t.Fatalf("expected error opening %s", name)
+		}
+	}
+}
+
+func TestFromFS_IndexesInMemoryTree(t *testing.T) {
+	idx, err := FromFS(context.Background(), fstest.MapFS{
+		"go.mod":      {Data: []byte("module example\n")},
+		"cmd/main.go": {Data: []byte("package main\n")},
+	}, "memory")
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	if idx.OnDisk() {
+		t.Fatal("an in-memory tree is not on disk")
+	}
+	want := []string{"cmd/main.go", "go.mod"}
+	if got := idx.Files(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Files() = %v, want %v", got, want)
+	}
+}
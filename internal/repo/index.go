@@ -0,0 +1,332 @@
+// Package repo builds a one-pass index of the repository under scan so
+// checks can query paths, sizes, modes, and file contents without each of
+// them hitting the disk again.
+//
+// The tree can be a directory, a .zip or .tar.gz archive (see Open), or any
+// fs.FS (see FromFS), so checks never depend on where it came from.
+//
+// The index is built once per run and shared by every check, including
+// checks running concurrently. It implements fs.FS along with fs.StatFS,
+// fs.ReadFileFS, and fs.ReadDirFS, so checks use the standard io/fs helpers
+// with slash-separated names relative to the scan root:
+//
+//	idx, err := repo.Open(ctx, root)
+//	info, err := fs.Stat(idx, ".github/CODEOWNERS")
+//	data, err := fs.ReadFile(idx, "README.md")
+//
+// The tree shape is captured up front; file contents are read lazily on
+// first use and cached. Directories the walk does not descend into, such as
+// .git, symlinked directories, or directories .gitignore excludes, are
+// served from the source tree on demand.
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sync"
+
+	"github.com/hittegit/yardstick/internal/gitignore"
+)
+
+// skipDirs are directory names whose contents are never indexed. They are
+// still reachable through the index via the source fallback.
+var skipDirs = []string{".git"}
+
+// Index is an in-memory snapshot of a repository tree.
+type Index struct {
+	root string
+	// onDisk is set when root is the directory the tree was read from.
+	onDisk bool
+	// src backs lazy reads and opaque directories. It is nil for archives
+	// whose contents were loaded up front.
+	src     fs.FS
+	entries map[string]*entry
+	// opaque holds indexed directories whose children were not walked.
+	opaque map[string]bool
+	files  []string
+
+	mu       sync.Mutex
+	contents map[string][]byte
+}
+
+type entry struct {
+	info     fs.FileInfo
+	children []fs.DirEntry // sorted by name, directories only
+}
+
+// Build walks the directory root once and returns its index. Unreadable
+// subdirectories are left to the disk fallback so their errors surface where
+// a check actually needs them.
+func Build(ctx context.Context, root string) (*Index, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	idx, err := FromFS(ctx, os.DirFS(root), root)
+	if err != nil {
+		return nil, err
+	}
+	idx.onDisk = true
+	return idx, nil
+}
+
+// FromFS walks fsys once and returns its index. root names the tree in
+// finding paths; it need not exist on disk.
+func FromFS(ctx context.Context, fsys fs.FS, root string) (*Index, error) {
+	info, err := fs.Stat(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	idx := newIndex(root, info)
+	idx.src = fsys
+	// Ignored directories such as node_modules/ are never descended into,
+	// so a walk only costs as much as the files that matter.
+	ign := gitignore.New(fsys)
+	err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, walkErr error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if walkErr != nil {
+			if name == "." {
+				return walkErr
+			}
+			idx.opaque[name] = true
+			return nil
+		}
+		if name == "." {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		// Resolve symlinks so Stat matches os.Stat: dangling links do not
+		// exist, and linked directories are not descended into, so their
+		// contents come from the source tree.
+		if info.Mode()&fs.ModeSymlink != 0 {
+			target, err := fs.Stat(fsys, name)
+			if err != nil {
+				return nil
+			}
+			info = renamed{target, info.Name()}
+			if target.IsDir() {
+				idx.opaque[name] = true
+			}
+		}
+		idx.add(name, info)
+
+		switch {
+		case d.IsDir() && slices.Contains(skipDirs, d.Name()):
+			idx.opaque[name] = true
+			return fs.SkipDir
+		case ign.Match(name, info.IsDir()):
+			if info.IsDir() {
+				idx.opaque[name] = true
+			}
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+		case info.Mode().IsRegular():
+			idx.files = append(idx.files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+func newIndex(root string, info fs.FileInfo) *Index {
+	return &Index{
+		root:     root,
+		entries:  map[string]*entry{".": {info: info}},
+		opaque:   map[string]bool{},
+		contents: map[string][]byte{},
+	}
+}
+
+// add records name under its already indexed parent. Names must arrive
+// parents first, in lexical order within each directory, as fs.WalkDir
+// delivers them.
+func (x *Index) add(name string, info fs.FileInfo) {
+	x.entries[name] = &entry{info: info}
+	parent := x.entries[path.Dir(name)]
+	parent.children = append(parent.children, fs.FileInfoToDirEntry(info))
+}
+
+// Root returns the name of the tree the index was built from: a directory
+// or archive path, or the root given to FromFS.
+func (x *Index) Root() string { return x.root }
+
+// OnDisk reports whether the index was built from the live directory at
+// Root, as opposed to an archive, a git commit, or another fs.FS, so paths
+// above the root can be resolved on disk.
+func (x *Index) OnDisk() bool { return x.onDisk }
+
+// Files returns the slash-separated paths of every indexed regular file in
+// lexical order, leaving out files .gitignore excludes. The returned slice
+// must not be modified.
+func (x *Index) Files() []string { return x.files }
+
+// Stat returns file info for name, following symlinks like os.Stat.
+func (x *Index) Stat(name string) (fs.FileInfo, error) {
+	e, src, err := x.lookup("stat", name)
+	if src {
+		return fs.Stat(x.src, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.info, nil
+}
+
+// ReadDir returns the entries of directory name sorted by file name.
+func (x *Index) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, src, err := x.lookup("readdir", name)
+	if src || (err == nil && x.opaque[name]) {
+		return fs.ReadDir(x.src, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !e.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return slices.Clone(e.children), nil
+}
+
+// ReadFile returns the contents of name. Contents are read from the source
+// once and cached; each call returns its own copy, as fs.ReadFileFS requires.
+func (x *Index) ReadFile(name string) ([]byte, error) {
+	b, err := x.cached(name)
+	return bytes.Clone(b), err
+}
+
+// cached returns the shared cached contents of name.
+func (x *Index) cached(name string) ([]byte, error) {
+	e, src, err := x.lookup("read", name)
+	if src {
+		return fs.ReadFile(x.src, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.info.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+
+	x.mu.Lock()
+	b, ok := x.contents[name]
+	x.mu.Unlock()
+	if ok {
+		return b, nil
+	}
+	// Read without holding the lock so concurrent checks are not serialized
+	// on disk I/O. Two racing readers store identical contents.
+	b, err = fs.ReadFile(x.src, name)
+	if err != nil {
+		return nil, err
+	}
+	x.mu.Lock()
+	x.contents[name] = b
+	x.mu.Unlock()
+	return b, nil
+}
+
+// Open opens name for reading. It exists so the index satisfies fs.FS;
+// prefer Stat, ReadFile, and ReadDir, which avoid the extra wrapping.
+func (x *Index) Open(name string) (fs.File, error) {
+	e, src, err := x.lookup("open", name)
+	if src {
+		return x.src.Open(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.info.IsDir() {
+		entries, err := x.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		return &dir{info: e.info, entries: entries}, nil
+	}
+	b, err := x.cached(name)
+	if err != nil {
+		return nil, err
+	}
+	return &file{info: e.info, Reader: bytes.NewReader(b)}, nil
+}
+
+// lookup finds name in the index. src is true when name lies beneath a
+// directory the walk did not descend into and must be served from the
+// source tree.
+func (x *Index) lookup(op, name string) (*entry, bool, error) {
+	if !fs.ValidPath(name) {
+		return nil, false, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if e, ok := x.entries[name]; ok {
+		return e, false, nil
+	}
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if x.opaque[dir] {
+			return nil, true, nil
+		}
+	}
+	return nil, false, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+// renamed reports a symlink target's info under the link's own name.
+type renamed struct {
+	fs.FileInfo
+	name string
+}
+
+func (r renamed) Name() string { return r.name }
+
+type file struct {
+	info fs.FileInfo
+	*bytes.Reader
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+type dir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dir) Close() error               { return nil }
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
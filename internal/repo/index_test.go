@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, body := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return root
+}
+
+func TestIndex_SatisfiesFS(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"README.md":                "# Title\n",
+		"go.mod":                   "module example\n",
+		".github/CODEOWNERS":       "* @team\n",
+		".github/workflows/ci.yml": "on: push\n",
+		"docs/guide.md":            "# Guide\n",
+	})
+	idx, err := Open(context.Background(), root)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if !idx.OnDisk() {
+		t.Fatal("a directory index should be on disk")
+	}
+	if err := fstest.TestFS(idx, "README.md", "go.mod", ".github/CODEOWNERS", ".github/workflows/ci.yml", "docs/guide.md"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{".github/CODEOWNERS", ".github/workflows/ci.yml", "README.md", "docs/guide.md", "go.mod"}
+	if got := idx.Files(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Files() = %v, want %v", got, want)
+	}
+	info, err := fs.Stat(idx, "go.mod")
+	if err != nil || info.Size() != int64(len("module example\n")) || !info.Mode().IsRegular() {
+		t.Fatalf("unexpected stat: %v %v", info, err)
+	}
+}
+
+func TestIndex_SnapshotAndCachedContents(t *testing.T) {
+	root := writeTree(t, map[string]string{"README.md": "v1"})
+	idx, err := Build(context.Background(), root)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	b, err := fs.ReadFile(idx, "README.md")
+	if err != nil || string(b) != "v1" {
+		t.Fatalf("read: %q %v", b, err)
+	}
+
+	// Later disk changes are not observed: the tree and read contents are
+	// a snapshot of the run.
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "LICENSE"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := fs.ReadFile(idx, "README.md"); string(b) != "v1" {
+		t.Fatalf("expected cached contents, got %q", b)
+	}
+	if _, err := fs.Stat(idx, "LICENSE"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected LICENSE to be absent from the index, got %v", err)
+	}
+	if _, err := fs.Stat(idx, "../etc/passwd"); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("expected invalid path error, got %v", err)
+	}
+}
+
+func TestIndex_SkippedAndLinkedDirsFallBackToDisk(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".git/HEAD":       "ref: refs/heads/main\n",
+		"shared/notes.md": "notes",
+		"pkg/main.go":     "package main\n",
+	})
+	if err := os.Symlink(filepath.Join(root, "shared"), filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := Build(context.Background(), root)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	for _, f := range idx.Files() {
+		if f == ".git/HEAD" || f == "linked/notes.md" {
+			t.Fatalf("%s should not be indexed", f)
+		}
+	}
+	if b, err := fs.ReadFile(idx, ".git/HEAD"); err != nil || string(b) != "ref: refs/heads/main\n" {
+		t.Fatalf("read .git/HEAD: %q %v", b, err)
+	}
+	if b, err := fs.ReadFile(idx, "linked/notes.md"); err != nil || string(b) != "notes" {
+		t.Fatalf("read through symlinked dir: %q %v", b, err)
+	}
+	if info, err := fs.Stat(idx, "linked"); err != nil || !info.IsDir() || info.Name() != "linked" {
+		t.Fatalf("stat symlinked dir: %v %v", info, err)
+	}
+	if entries, err := fs.ReadDir(idx, "linked"); err != nil || len(entries) != 1 {
+		t.Fatalf("readdir symlinked dir: %v %v", entries, err)
+	}
+	if _, err := fs.Stat(idx, "dangling"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("dangling symlink should not exist, got %v", err)
+	}
+}
+
+func TestBuild_RootMustBeDirectory(t *testing.T) {
+	root := writeTree(t, map[string]string{"file": ""})
+	if _, err := Build(context.Background(), filepath.Join(root, "file")); err == nil {
+		t.Fatal("expected error for non-directory root")
+	}
+	if _, err := Build(context.Background(), filepath.Join(root, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Build(ctx, root); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+func TestIndex_RespectsGitignore(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore":                   "node_modules/\n*.log\n!keep.log\n",
+		"node_modules/react/index.js":  "",
+		"app.log":                      "",
+		"keep.log":                     "",
+		"web/.gitignore":               "dist/\n",
+		"web/dist/bundle.js":           "",
+		"web/src/index.js":             "",
+		"web/node_modules/x/package.j": "",
+	})
+	idx, err := Build(context.Background(), root)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	want := []string{".gitignore", "keep.log", "web/.gitignore", "web/src/index.js"}
+	if got := idx.Files(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Files() = %v, want %v", got, want)
+	}
+	// Ignored paths are skipped by the walk but still reachable.
+	if info, err := fs.Stat(idx, "node_modules"); err != nil || !info.IsDir() {
+		t.Fatalf("stat ignored dir: %v %v", info, err)
+	}
+	if _, err := fs.Stat(idx, "web/dist/bundle.js"); err != nil {
+		t.Fatalf("stat file in ignored dir: %v", err)
+	}
+	if _, err := fs.Stat(idx, "app.log"); err != nil {
+		t.Fatalf("stat ignored file: %v", err)
+	}
+}
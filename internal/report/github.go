@@ -0,0 +1,111 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/baseline"
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// GitHub Actions output.
+//
+// Findings become workflow commands (::notice, ::warning, ::error) so they
+// show up as annotations on the run and the pull request diff. The check
+// status table is rendered separately as Markdown for $GITHUB_STEP_SUMMARY.
+
+// WriteGitHubAnnotations emits one workflow command per active finding, with
+// file paths relative to root, then an error for each errored check and the
+// run summary.
+func WriteGitHubAnnotations(w io.Writer, out Output, root string) error {
+	for _, f := range activeFindings(out.Findings) {
+		props := []string{}
+		if rel := baseline.RelPath(root, f.Path); rel != "." {
+			props = append(props, "file="+escapeProperty(rel))
+			props = append(props, positionProps(f)...)
+		}
+		props = append(props, "title="+escapeProperty("yardstick "+f.Check))
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubCommand(f.Level), strings.Join(props, ","), escapeData(f.Message)); err != nil {
+			return err
+		}
+	}
+	for _, s := range out.Checks {
+		if s.Status != "errored" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "::error title=%s::%s\n", escapeProperty("yardstick "+s.Check), escapeData("Check errored: "+s.Error)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "yardstick: %s\n", out.Summary)
+	return err
+}
+
+// WriteStepSummary renders the verbose table content as Markdown suitable for
+// the GitHub Actions job summary.
+func WriteStepSummary(w io.Writer, out Output, root string) error {
+	var b strings.Builder
+	b.WriteString("## Yardstick\n\n")
+	b.WriteString(mdCell(out.Summary) + "\n\n")
+	writeMarkdownStatusTable(&b, out.Checks, true)
+
+	active := activeFindings(out.Findings)
+	if len(active) == 0 {
+		b.WriteString("\nNo findings. Repository hygiene checks look good.\n")
+	} else {
+		b.WriteString("\n### Findings\n\n")
+		b.WriteString("| Check | Level | Path | Message |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, f := range sortedFindings(active) {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", f.Check, f.Level, mdCell(location(baseline.RelPath(root, f.Path), f)), mdCell(f.Message))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// positionProps returns the line and column properties for f, omitting any
+// the check did not set.
+func positionProps(f checks.Finding) []string {
+	var props []string
+	for _, p := range []struct {
+		name  string
+		value int
+	}{
+		{"line", f.Line},
+		{"col", f.Column},
+		{"endLine", f.EndLine},
+		{"endColumn", f.EndColumn},
+	} {
+		if p.value > 0 {
+			props = append(props, fmt.Sprintf("%s=%d", p.name, p.value))
+		}
+	}
+	return props
+}
+
+func githubCommand(l checks.Level) string {
+	switch l {
+	case checks.LevelError:
+		return "error"
+	case checks.LevelWarn:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeData escapes a workflow command message.
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeProperty escapes a workflow command property value.
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+func TestWriteGitHubAnnotations_CommandsPerFinding(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "readme", Status: "fail"}}, []checks.Finding{
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/docs/a,b.md", Message: "100% broken\nlink", Line: 3, Column: 5, EndLine: 3, EndColumn: 12},
+		{Check: "license", Level: checks.LevelError, Path: "/repo/LICENSE", Message: "LICENSE missing"},
+		{Check: "manifest", Level: checks.LevelInfo, Path: "/repo", Message: "No manifest"},
+		{Check: "changelog", Level: checks.LevelWarn, Path: "/repo/CHANGELOG.md", Message: "known", Suppressed: true},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"::warning file=docs/a%2Cb.md,line=3,col=5,endLine=3,endColumn=12,title=yardstick readme::100%25 broken%0Alink",
+		"::error file=LICENSE,title=yardstick license::LICENSE missing",
+		"::notice title=yardstick manifest::No manifest",
+		"yardstick: " + out.Summary,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected lines:\n%s", buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("line %d:\n got %q\nwant %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestWriteStepSummary_MarkdownTables(t *testing.T) {
+	out := Output{
+		Summary: "1 of 2 checks failed.",
+		Checks: []CheckStatus{
+			{Check: "readme", Status: "fail", Level: checks.LevelWarn, Findings: 1, WhyImportant: "docs | matter", HowToResolve: "add sections"},
+			{Check: "manifest", Status: "pass"},
+		},
+		Findings: []checks.Finding{
+			{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "Missing section: <CI>"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteStepSummary(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{
+		"1 of 2 checks failed.",
+		"| Check | Status | Level | Findings | Details |",
+		"| `manifest` | pass | - | 0 | OK |",
+		"**Why:** docs \\| matter<br>**Fix:** add sections",
+		"| `readme` | warn | README.md | Missing section: &lt;CI&gt; |",
+	} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("step summary missing %q:\n%s", want, s)
+		}
+	}
+	if strings.Index(s, "`manifest`") > strings.Index(s, "`readme` | fail") {
+		t.Fatalf("checks should be sorted by key:\n%s", s)
+	}
+}
+
+func TestWriteGitHubAnnotations_ErroredChecks(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "readme_links", Status: "errored", Error: "open: permission denied"}}, nil)
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "::error title=yardstick readme_links::Check errored: open: permission denied\n") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/hittegit/yardstick/internal/baseline"
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// WriteHTML renders out as a single self-contained HTML page with inline CSS
+// and a small inline script that sorts tables by check or level. It is built
+// from the same Output as JSON, so both views always agree.
+func WriteHTML(w io.Writer, out Output, root string) error {
+	data := htmlReport{Output: out}
+	for _, s := range sortedStatuses(out.Checks) {
+		data.Statuses = append(data.Statuses, htmlStatus{CheckStatus: s, Rank: levelRank(s.Level)})
+	}
+	for _, f := range sortedFindings(out.Findings) {
+		data.Rows = append(data.Rows, htmlFinding{
+			Finding: f,
+			RelPath: location(baseline.RelPath(root, f.Path), f),
+			Rank:    levelRank(f.Level),
+		})
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+type htmlReport struct {
+	Output
+	Statuses []htmlStatus
+	Rows     []htmlFinding
+}
+
+type htmlStatus struct {
+	CheckStatus
+	Rank int
+}
+
+type htmlFinding struct {
+	checks.Finding
+	RelPath string
+	Rank    int
+}
+
+// levelRank orders levels for sorting, most severe first when descending.
+func levelRank(l checks.Level) int {
+	switch l {
+	case checks.LevelError:
+		return 3
+	case checks.LevelWarn:
+		return 2
+	case checks.LevelInfo:
+		return 1
+	}
+	return 0
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Yardstick Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { font-size: 1.6rem; margin-bottom: 0.25rem; }
+h2 { font-size: 1.2rem; margin-top: 2rem; }
+.summary { font-size: 1.05rem; }
+.counts span { display: inline-block; margin-right: 1rem; }
+table { border-collapse: collapse; width: 100%; margin-top: 0.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+th.sortable { cursor: pointer; user-select: none; }
+th.sortable::after { content: " \2195"; color: #8c959f; }
+td.guidance { font-size: 0.9rem; color: #424a53; }
+.status-fail, .status-errored, .level-error { color: #cf222e; font-weight: 600; }
+.level-warn { color: #9a6700; font-weight: 600; }
+.status-pass, .level-info { color: #1a7f37; }
+tr.suppressed td { color: #8c959f; }
+code { font-size: 0.9rem; }
+</style>
+</head>
+<body>
+<h1>Yardstick Report</h1>
+<p class="summary">{{.Summary}}</p>
+{{- with .Revision}}
+<p class="revision">Revision <code>{{.Ref}}</code> at <code>{{.Commit}}</code></p>
+{{- end}}
+<p class="counts"><span>error: {{.Counts.Error}}</span><span>warn: {{.Counts.Warn}}</span><span>info: {{.Counts.Info}}</span>{{if .Counts.Suppressed}}<span>suppressed: {{.Counts.Suppressed}}</span>{{end}}</p>
+
+<h2>Checks</h2>
+<table class="sortable" id="checks">
+<thead><tr><th class="sortable" data-col="0">Check</th><th>Status</th><th class="sortable" data-col="2">Level</th><th>Findings</th><th>Details</th></tr></thead>
+<tbody>
+{{- range .Statuses}}
+<tr><td data-sort="{{.Check}}"><code>{{.Check}}</code></td><td class="status-{{.Status}}">{{.Status}}</td><td class="level-{{.Level}}" data-sort="{{.Rank}}">{{if .Level}}{{.Level}}{{else}}-{{end}}</td><td>{{.Findings}}</td><td class="guidance">{{.Description}}{{if eq .Status "fail"}}<br><strong>Why:</strong> {{.WhyImportant}}<br><strong>Fix:</strong> {{.HowToResolve}}{{else if eq .Status "errored"}}<br><strong>Error:</strong> {{.Error}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+
+<h2>Findings</h2>
+{{- if .Rows}}
+<table class="sortable" id="findings">
+<thead><tr><th class="sortable" data-col="0">Check</th><th class="sortable" data-col="1">Level</th><th>Path</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if .Suppressed}} class="suppressed"{{end}}><td data-sort="{{.Check}}"><code>{{.Check}}</code></td><td class="level-{{.Level}}" data-sort="{{.Rank}}">{{.Level}}{{if .Suppressed}} (suppressed){{end}}</td><td><code>{{.RelPath}}</code></td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No findings. Repository hygiene checks look good.</p>
+{{- end}}
+
+<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th.sortable").forEach(function (th) {
+    var desc = false;
+    th.addEventListener("click", function () {
+      var col = Number(th.dataset.col);
+      var body = table.tBodies[0];
+      var rows = Array.from(body.rows);
+      desc = !desc;
+      rows.sort(function (a, b) {
+        var x = a.cells[col].dataset.sort, y = b.cells[col].dataset.sort;
+        var cmp = isNaN(x) || isNaN(y) ? x.localeCompare(y) : Number(x) - Number(y);
+        return desc ? -cmp : cmp;
+      });
+      rows.forEach(function (r) { body.appendChild(r); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
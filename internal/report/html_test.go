@@ -0,0 +1,51 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+func TestWriteHTML_SelfContainedAndEscaped(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "readme", Description: "README present", Status: "fail", Level: checks.LevelWarn, Findings: 1, WhyImportant: "docs matter", HowToResolve: "add sections"},
+		{Check: "license", Description: "LICENSE present", Status: "pass"},
+	}, []checks.Finding{
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "Missing <script>alert(1)</script>"},
+		{Check: "license", Level: checks.LevelError, Path: "/repo/LICENSE", Message: "known", Suppressed: true},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"<code>README.md</code>",
+		"Missing &lt;script&gt;alert(1)&lt;/script&gt;",
+		`<td class="level-warn" data-sort="2">warn</td>`,
+		`<tr class="suppressed">`,
+		"error (suppressed)",
+		"<strong>Why:</strong> docs matter",
+		"suppressed: 1",
+	} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("missing %q in:\n%s", want, s)
+		}
+	}
+	for _, bad := range []string{"<link ", "<script src=", "http://", "https://"} {
+		if strings.Contains(s, bad) {
+			t.Fatalf("report should be self-contained, found %q", bad)
+		}
+	}
+}
+
+func TestLevelRank_Ordering(t *testing.T) {
+	if !(levelRank(checks.LevelError) > levelRank(checks.LevelWarn) && levelRank(checks.LevelWarn) > levelRank(checks.LevelInfo)) {
+		t.Fatal("levels should rank error > warn > info")
+	}
+}
@@ -0,0 +1,160 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// JUnit XML output so CI systems (Jenkins, GitLab, and others) show each
+// executed check as a test case. Failed checks carry their findings and
+// guidance in the failure body, errored checks report an error element, and
+// passing checks with informational findings list them in system-out.
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit renders out as a JUnit XML report with one test case per check.
+func WriteJUnit(w io.Writer, out Output) error {
+	suite := junitSuite{
+		Name:  "yardstick",
+		Tests: len(out.Checks),
+		Properties: []junitProperty{
+			{Name: "summary", Value: out.Summary},
+			{Name: "counts.info", Value: strconv.Itoa(out.Counts.Info)},
+			{Name: "counts.warn", Value: strconv.Itoa(out.Counts.Warn)},
+			{Name: "counts.error", Value: strconv.Itoa(out.Counts.Error)},
+			{Name: "counts.suppressed", Value: strconv.Itoa(out.Counts.Suppressed)},
+		},
+	}
+
+	if out.Revision != nil {
+		suite.Properties = append(suite.Properties,
+			junitProperty{Name: "revision.ref", Value: out.Revision.Ref},
+			junitProperty{Name: "revision.commit", Value: out.Revision.Commit},
+		)
+	}
+
+	byCheck := make(map[string][]checks.Finding)
+	for _, f := range out.Findings {
+		byCheck[f.Check] = append(byCheck[f.Check], f)
+	}
+
+	for _, s := range out.Checks {
+		tc := junitCase{Name: s.Check, Classname: "yardstick." + s.Check}
+		var active, suppressed []checks.Finding
+		for _, f := range byCheck[s.Check] {
+			if f.Suppressed {
+				suppressed = append(suppressed, f)
+			} else {
+				active = append(active, f)
+			}
+		}
+
+		switch {
+		case s.Status == "errored":
+			suite.Errors++
+			tc.Error = &junitFailure{Message: s.Error, Type: "errored"}
+		case s.Status == "fail":
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: failureMessage(active),
+				Type:    string(s.Level),
+				Body:    failureBody(s, active),
+			}
+		case len(active) > 0:
+			tc.SystemOut = findingLines(active)
+		}
+		if len(suppressed) > 0 {
+			tc.SystemOut += "Suppressed by baseline:\n" + findingLines(suppressed)
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	doc := junitSuites{
+		Name:     "yardstick",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []junitSuite{suite},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func failureMessage(fs []checks.Finding) string {
+	if len(fs) == 0 {
+		return ""
+	}
+	if len(fs) == 1 {
+		return fs[0].Message
+	}
+	return fmt.Sprintf("%s (and %d more)", fs[0].Message, len(fs)-1)
+}
+
+func failureBody(s CheckStatus, fs []checks.Finding) string {
+	var b strings.Builder
+	b.WriteString(findingLines(fs))
+	if s.WhyImportant != "" {
+		b.WriteString("\nWhy it matters: " + s.WhyImportant + "\n")
+	}
+	if s.HowToResolve != "" {
+		b.WriteString("How to resolve: " + s.HowToResolve + "\n")
+	}
+	return b.String()
+}
+
+func findingLines(fs []checks.Finding) string {
+	var b strings.Builder
+	for _, f := range fs {
+		fmt.Fprintf(&b, "[%s] %s: %s\n", f.Level, location(f.Path, f), f.Message)
+	}
+	return b.String()
+}
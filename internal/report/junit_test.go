@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+func TestWriteJUnit_CasesPerCheck(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "manifest", Status: "pass", Level: checks.LevelInfo, Findings: 1},
+		{
+			Check:        "readme",
+			Status:       "fail",
+			Level:        checks.LevelWarn,
+			Findings:     2,
+			WhyImportant: "docs matter",
+			HowToResolve: "add README sections",
+		},
+		{Check: "license", Status: "pass"},
+	}, []checks.Finding{
+		{Check: "manifest", Level: checks.LevelInfo, Path: "/repo/go.mod", Message: "Go project detected via go.mod"},
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "Missing section: ## CI"},
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "Missing section: <License>"},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, out); err != nil {
+		t.Fatalf("write junit: %v", err)
+	}
+	s := buf.String()
+	if !strings.HasPrefix(s, "<?xml") {
+		t.Fatalf("missing XML header: %s", s)
+	}
+
+	var doc struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Properties []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"properties>property"`
+			Cases []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+					Body    string `xml:",chardata"`
+				} `xml:"failure"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, s)
+	}
+	if doc.Tests != 3 || doc.Failures != 1 || len(doc.Suites) != 1 {
+		t.Fatalf("unexpected totals: %+v", doc)
+	}
+	props := map[string]string{}
+	for _, p := range doc.Suites[0].Properties {
+		props[p.Name] = p.Value
+	}
+	if props["counts.info"] != "1" || props["counts.warn"] != "2" || props["counts.error"] != "0" {
+		t.Fatalf("suite counts should match Output.Counts: %+v", props)
+	}
+
+	cases := doc.Suites[0].Cases
+	if len(cases) != 3 {
+		t.Fatalf("expected 3 test cases, got %d", len(cases))
+	}
+	if cases[0].Name != "manifest" || cases[0].Failure != nil || !strings.Contains(cases[0].SystemOut, "Go project detected") {
+		t.Fatalf("info-only check should pass with system-out: %+v", cases[0])
+	}
+	readme := cases[1]
+	if readme.Failure == nil || readme.Failure.Type != "warn" {
+		t.Fatalf("expected readme failure: %+v", readme)
+	}
+	if readme.Failure.Message != "Missing section: ## CI (and 1 more)" {
+		t.Fatalf("unexpected failure message: %q", readme.Failure.Message)
+	}
+	if !strings.Contains(readme.Failure.Body, "Missing section: <License>") ||
+		!strings.Contains(readme.Failure.Body, "Why it matters: docs matter") ||
+		!strings.Contains(readme.Failure.Body, "How to resolve: add README sections") {
+		t.Fatalf("failure body missing findings or guidance: %q", readme.Failure.Body)
+	}
+	if cases[2].Failure != nil || cases[2].SystemOut != "" {
+		t.Fatalf("clean check should be a bare passing case: %+v", cases[2])
+	}
+}
+
+func TestWriteJUnit_ErroredCheckIsError(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "readme_links", Status: "errored", Level: checks.LevelError, Error: "permission denied"},
+		{Check: "license", Status: "pass"},
+	}, nil)
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, out); err != nil {
+		t.Fatalf("write junit: %v", err)
+	}
+	var doc struct {
+		Errors int `xml:"errors,attr"`
+		Suites []struct {
+			Errors int `xml:"errors,attr"`
+			Cases  []struct {
+				Name  string `xml:"name,attr"`
+				Error *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"error"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.Errors != 1 || doc.Suites[0].Errors != 1 {
+		t.Fatalf("expected one error, got %d/%d", doc.Errors, doc.Suites[0].Errors)
+	}
+	c := doc.Suites[0].Cases[0]
+	if c.Error == nil || c.Error.Message != "permission denied" || doc.Suites[0].Cases[1].Error != nil {
+		t.Fatalf("unexpected cases: %+v", doc.Suites[0].Cases)
+	}
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/baseline"
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// WriteMarkdown renders out as a Markdown document suitable for pull request
+// comments: summary, counts, the check status table, and a collapsible
+// section of findings and guidance per check.
+func WriteMarkdown(w io.Writer, out Output, root string) error {
+	var b strings.Builder
+	b.WriteString("# Yardstick Report\n\n")
+	fmt.Fprintf(&b, "**Summary:** %s\n\n", mdCell(out.Summary))
+	if out.Revision != nil {
+		fmt.Fprintf(&b, "**Revision:** `%s` at `%s`\n\n", mdCell(out.Revision.Ref), out.Revision.Commit)
+	}
+	fmt.Fprintf(&b, "**Counts:** %d error, %d warn, %d info", out.Counts.Error, out.Counts.Warn, out.Counts.Info)
+	if out.Counts.Suppressed > 0 {
+		fmt.Fprintf(&b, ", %d suppressed", out.Counts.Suppressed)
+	}
+	b.WriteString("\n\n## Checks\n\n")
+	writeMarkdownStatusTable(&b, out.Checks, false)
+
+	byCheck := make(map[string][]checks.Finding)
+	for _, f := range sortedFindings(out.Findings) {
+		byCheck[f.Check] = append(byCheck[f.Check], f)
+	}
+	if len(byCheck) == 0 {
+		b.WriteString("\nNo findings. Repository hygiene checks look good.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	b.WriteString("\n## Findings\n")
+	for _, s := range sortedStatuses(out.Checks) {
+		fs := byCheck[s.Check]
+		if len(fs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n<details>\n<summary><code>%s</code>: %s, %s</summary>\n\n", s.Check, s.Status, plural(len(fs), "finding"))
+		b.WriteString("| Level | Path | Message |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, f := range fs {
+			level := string(f.Level)
+			if f.Suppressed {
+				level += " (suppressed)"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", level, mdCell(location(baseline.RelPath(root, f.Path), f)), mdCell(f.Message))
+		}
+		if s.Status == "fail" {
+			fmt.Fprintf(&b, "\n**Why it matters:** %s\n\n**How to resolve:** %s\n", mdCell(s.WhyImportant), mdCell(s.HowToResolve))
+		}
+		b.WriteString("\n</details>\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownStatusTable renders the per-check status table. With guidance
+// set, failed checks carry why/how text inline in a Details column, matching
+// PrintVerboseTable; otherwise the check description is shown. Errored checks
+// always show their error.
+func writeMarkdownStatusTable(b *strings.Builder, statuses []CheckStatus, guidance bool) {
+	last := "Description"
+	if guidance {
+		last = "Details"
+	}
+	fmt.Fprintf(b, "| Check | Status | Level | Findings | %s |\n", last)
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, s := range sortedStatuses(statuses) {
+		level := string(s.Level)
+		if level == "" {
+			level = "-"
+		}
+		cell := mdCell(s.Description)
+		switch {
+		case s.Status == "errored":
+			cell = "**Error:** " + mdCell(s.Error)
+		case guidance:
+			cell = "OK"
+			if s.Status == "fail" {
+				cell = "**Why:** " + mdCell(s.WhyImportant) + "<br>**Fix:** " + mdCell(s.HowToResolve)
+			}
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %d | %s |\n", s.Check, s.Status, level, s.Findings, cell)
+	}
+}
+
+// mdCellReplacer escapes Markdown table delimiters and HTML so messages like
+// "<License>" are not swallowed by the renderer.
+var mdCellReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"|", "\\|",
+	"\r", "",
+	"\n", "<br>",
+)
+
+// mdCell makes text safe for a single Markdown table cell.
+func mdCell(s string) string {
+	return mdCellReplacer.Replace(s)
+}
+
+// sortedStatuses returns a copy of statuses ordered by check key.
+func sortedStatuses(statuses []CheckStatus) []CheckStatus {
+	out := append([]CheckStatus(nil), statuses...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Check < out[j].Check
+	})
+	return out
+}
+
+// sortedFindings returns a copy of fs in the stable check/path order used by
+// PrintTable.
+func sortedFindings(fs []checks.Finding) []checks.Finding {
+	out := append([]checks.Finding(nil), fs...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Check == out[j].Check {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Check < out[j].Check
+	})
+	return out
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+func TestWriteMarkdown_ChecksAndFindings(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "readme", Description: "README present", Status: "fail", Level: checks.LevelWarn, Findings: 1, WhyImportant: "docs matter", HowToResolve: "add sections"},
+		{Check: "license", Description: "LICENSE present", Status: "pass", Suppressed: 1},
+		{Check: "manifest", Description: "Manifest detected", Status: "pass"},
+	}, []checks.Finding{
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "Missing section: <Usage> | install"},
+		{Check: "license", Level: checks.LevelError, Path: "/repo/LICENSE", Message: "LICENSE missing", Suppressed: true},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{
+		"# Yardstick Report",
+		"**Counts:** 0 error, 1 warn, 0 info, 1 suppressed",
+		"| Check | Status | Level | Findings | Description |",
+		"| `manifest` | pass | - | 0 | Manifest detected |",
+		"<summary><code>readme</code>: fail, 1 finding</summary>",
+		"| warn | README.md | Missing section: &lt;Usage&gt; \\| install |",
+		"| error (suppressed) | LICENSE | LICENSE missing |",
+		"**Why it matters:** docs matter",
+	} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("missing %q in:\n%s", want, s)
+		}
+	}
+	if strings.Contains(s, "<code>manifest</code>") {
+		t.Fatalf("checks without findings should not get a details block:\n%s", s)
+	}
+	if strings.Index(s, "<code>license</code>") > strings.Index(s, "<code>readme</code>") {
+		t.Fatalf("findings should be grouped in check order:\n%s", s)
+	}
+}
+
+func TestWriteMarkdown_NoFindings(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "readme", Status: "pass"}}, nil)
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, out, "/repo"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No findings.") || strings.Contains(buf.String(), "## Findings") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
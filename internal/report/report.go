@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/hittegit/yardstick/internal/baseline"
 	"github.com/hittegit/yardstick/internal/checks"
 )
 
@@ -21,28 +23,56 @@ type Output struct {
 	Checks   []CheckStatus    `json:"checks"`
 	Findings []checks.Finding `json:"findings"`
 	Counts   struct {
-		Info  int `json:"info"`
-		Warn  int `json:"warn"`
-		Error int `json:"error"`
+		Info       int `json:"info"`
+		Warn       int `json:"warn"`
+		Error      int `json:"error"`
+		Suppressed int `json:"suppressed"`
 	} `json:"counts"`
+	Baseline   *BaselineStatus    `json:"baseline,omitempty"`
+	Ecosystems []checks.Ecosystem `json:"ecosystems"`
+	Revision   *Revision          `json:"revision,omitempty"`
 }
 
-// CheckStatus describes pass/fail status for an executed check.
+// Revision identifies the git commit scanned with -rev. It is nil when the
+// working tree or an archive was scanned.
+type Revision struct {
+	// Ref is the revision as given, for example origin/main or v1.2.0.
+	Ref string `json:"ref"`
+	// Commit is the full SHA the revision resolved to.
+	Commit string `json:"commit"`
+}
+
+// CheckStatus describes pass/fail status for an executed check. Status is
+// "pass", "fail", or "errored" when the check could not complete, in which
+// case Error holds the reason.
 type CheckStatus struct {
 	Check        string       `json:"check"`
 	Description  string       `json:"description"`
 	Status       string       `json:"status"`
 	Level        checks.Level `json:"level,omitempty"`
 	Findings     int          `json:"findings"`
+	Suppressed   int          `json:"suppressed,omitempty"`
 	WhyImportant string       `json:"why_important,omitempty"`
 	HowToResolve string       `json:"how_to_resolve,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// BaselineStatus describes how a -baseline file was applied to the run.
+type BaselineStatus struct {
+	Path  string           `json:"path"`
+	Stale []baseline.Entry `json:"stale"`
 }
 
 // FromFindings converts a slice of findings into an Output, computing counts.
+// Findings suppressed by a baseline are counted separately from the levels.
 func FromFindings(fs []checks.Finding) Output {
 	out := Output{Findings: fs}
 	out.Summary = "Findings-only output"
 	for _, f := range fs {
+		if f.Suppressed {
+			out.Counts.Suppressed++
+			continue
+		}
 		switch f.Level {
 		case checks.LevelInfo:
 			out.Counts.Info++
@@ -61,21 +91,32 @@ func FromRun(statuses []CheckStatus, fs []checks.Finding) Output {
 	out.Checks = statuses
 
 	total := len(statuses)
-	failed := 0
+	failed, errored := 0, 0
 	for _, s := range statuses {
-		if s.Status == "fail" {
+		switch s.Status {
+		case "fail":
 			failed++
+		case "errored":
+			errored++
 		}
 	}
 	if total == 0 {
 		out.Summary = "No checks were executed."
 		return out
 	}
-	if failed == 0 {
+	switch {
+	case failed == 0 && errored == 0:
 		out.Summary = fmt.Sprintf("All checks passed (%d/%d).", total, total)
-		return out
+	case errored == 0:
+		out.Summary = fmt.Sprintf("%d of %d checks failed.", failed, total)
+	case failed == 0:
+		out.Summary = fmt.Sprintf("%d of %d checks errored.", errored, total)
+	default:
+		out.Summary = fmt.Sprintf("%d of %d checks failed, %d errored.", failed, total, errored)
+	}
+	if out.Counts.Suppressed > 0 {
+		out.Summary += fmt.Sprintf(" %d findings suppressed by baseline.", out.Counts.Suppressed)
 	}
-	out.Summary = fmt.Sprintf("%d of %d checks failed.", failed, total)
 	return out
 }
 
@@ -94,14 +135,25 @@ func PrintTable(w io.Writer, fs []checks.Finding) {
 	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "CHECK\tLEVEL\tPATH\tMESSAGE\tFIXED")
 	for _, f := range fs {
-		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", f.Check, f.Level, f.Path, f.Message, f.Fixed)
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", f.Check, f.Level, location(f.Path, f), f.Message, f.Fixed)
 	}
 	_ = tw.Flush() //nolint:errcheck // best-effort flush for tabwriter
 }
 
 // PrintVerboseTable writes a summary plus per-check status details.
 func PrintVerboseTable(w io.Writer, out Output) {
-	_, _ = fmt.Fprintf(w, "SUMMARY: %s\n\n", out.Summary)
+	_, _ = fmt.Fprintf(w, "SUMMARY: %s\n", out.Summary)
+	if out.Revision != nil {
+		_, _ = fmt.Fprintf(w, "REVISION: %s (%s)\n", out.Revision.Ref, out.Revision.Commit)
+	}
+	if len(out.Ecosystems) > 0 {
+		names := make([]string, 0, len(out.Ecosystems))
+		for _, e := range out.Ecosystems {
+			names = append(names, e.Name)
+		}
+		_, _ = fmt.Fprintf(w, "ECOSYSTEMS: %s\n", strings.Join(names, ", "))
+	}
+	_, _ = fmt.Fprintln(w)
 
 	statuses := append([]CheckStatus(nil), out.Checks...)
 	sort.Slice(statuses, func(i, j int) bool {
@@ -116,18 +168,61 @@ func PrintVerboseTable(w io.Writer, out Output) {
 			level = "-"
 		}
 		details := "OK"
-		if s.Status == "fail" {
+		switch s.Status {
+		case "fail":
 			details = "Why: " + s.WhyImportant + " | Fix: " + s.HowToResolve
+		case "errored":
+			details = "Error: " + s.Error
 		}
 		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", s.Check, s.Status, level, s.Findings, details)
 	}
 	_ = tw.Flush() //nolint:errcheck // best-effort flush for tabwriter
 
-	if len(out.Findings) == 0 {
+	printStaleBaseline(w, out.Baseline)
+
+	active := activeFindings(out.Findings)
+	if len(active) == 0 {
 		_, _ = fmt.Fprintln(w, "\nNo findings. Repository hygiene checks look good.")
 		return
 	}
 
 	_, _ = fmt.Fprintln(w, "\nFINDINGS")
-	PrintTable(w, out.Findings)
+	PrintTable(w, active)
+}
+
+// location appends the finding's line and column to path, when known, in
+// the path:line:col form editors and terminals recognize.
+func location(path string, f checks.Finding) string {
+	switch {
+	case f.Line == 0:
+		return path
+	case f.Column == 0:
+		return fmt.Sprintf("%s:%d", path, f.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", path, f.Line, f.Column)
+	}
+}
+
+// activeFindings returns the findings not suppressed by a baseline.
+func activeFindings(fs []checks.Finding) []checks.Finding {
+	var out []checks.Finding
+	for _, f := range fs {
+		if !f.Suppressed {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func printStaleBaseline(w io.Writer, b *BaselineStatus) {
+	if b == nil || len(b.Stale) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nSTALE BASELINE ENTRIES (%s)\n", b.Path)
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tPATH\tMESSAGE")
+	for _, e := range b.Stale {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", e.Check, e.Path, e.Message)
+	}
+	_ = tw.Flush() //nolint:errcheck // best-effort flush for tabwriter
 }
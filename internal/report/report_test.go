@@ -23,6 +23,19 @@ func TestFromFindings_Counts(t *testing.T) {
 	}
 }
 
+func TestFromRun_SuppressedFindingsCountedSeparately(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "license", Status: "pass"}}, []checks.Finding{
+		{Check: "license", Level: checks.LevelWarn, Suppressed: true},
+		{Check: "readme", Level: checks.LevelWarn},
+	})
+	if out.Counts.Warn != 1 || out.Counts.Suppressed != 1 {
+		t.Fatalf("unexpected counts: %+v", out.Counts)
+	}
+	if !strings.Contains(out.Summary, "1 findings suppressed by baseline") {
+		t.Fatalf("summary should mention suppressed findings: %q", out.Summary)
+	}
+}
+
 // normalize splits a tabwriter line into columns by collapsing runs of 2+ spaces.
 func normalize(line string) []string {
 	line = strings.TrimSpace(line)
@@ -63,6 +76,35 @@ func TestPrintTable_SortsAndFormats(t *testing.T) {
 	}
 }
 
+func TestPrintTable_ShowsLineAndColumn(t *testing.T) {
+	var buf bytes.Buffer
+	PrintTable(&buf, []checks.Finding{
+		{Check: "a", Level: checks.LevelWarn, Path: "README.md", Message: "m0", Line: 3, Column: 7},
+		{Check: "b", Level: checks.LevelWarn, Path: "go.mod", Message: "m1", Line: 2},
+		{Check: "c", Level: checks.LevelWarn, Path: "LICENSE", Message: "m2"},
+	})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, want := range []string{"README.md:3:7", "go.mod:2", "LICENSE"} {
+		if got := normalize(lines[i+1])[2]; got != want {
+			t.Fatalf("row %d path = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestOutputJSON_OmitsUnsetPositions(t *testing.T) {
+	b, err := json.Marshal(FromFindings([]checks.Finding{
+		{Check: "a", Path: "x", Line: 2, Column: 5},
+		{Check: "b", Path: "y"},
+	}))
+	if err != nil {
+		t.Fatalf("marshal output: %v", err)
+	}
+	s := string(b)
+	if !strings.Contains(s, `"line":2,"column":5}`) || strings.Count(s, `"line"`) != 1 || strings.Contains(s, "end_line") {
+		t.Fatalf("unexpected position fields: %s", s)
+	}
+}
+
 func TestOutputJSON_IncludesStableFindingFields(t *testing.T) {
 	out := FromFindings([]checks.Finding{
 		{Check: "manifest", Level: checks.LevelInfo, Path: "/repo/go.mod", Message: "ok"},
@@ -80,6 +122,49 @@ func TestOutputJSON_IncludesStableFindingFields(t *testing.T) {
 	}
 }
 
+func TestOutput_Ecosystems(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "manifest", Status: "pass"}}, nil)
+	out.Ecosystems = []checks.Ecosystem{
+		{ID: "go", Name: "Go", Manifests: []string{"go.mod"}},
+		{ID: "node", Name: "Node", Manifests: []string{"package.json"}},
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("marshal output: %v", err)
+	}
+	if !strings.Contains(string(b), `"ecosystems":[{"id":"go","name":"Go","manifests":["go.mod"]},{"id":"node"`) {
+		t.Fatalf("unexpected ecosystems JSON: %s", b)
+	}
+
+	var buf bytes.Buffer
+	PrintVerboseTable(&buf, out)
+	if !strings.Contains(buf.String(), "ECOSYSTEMS: Go, Node\n") {
+		t.Fatalf("table missing ecosystems line:\n%s", buf.String())
+	}
+}
+
+func TestOutput_Revision(t *testing.T) {
+	out := FromRun([]CheckStatus{{Check: "manifest", Status: "pass"}}, nil)
+	b, _ := json.Marshal(out)
+	if strings.Contains(string(b), `"revision"`) {
+		t.Fatalf("revision should be omitted for working tree scans: %s", b)
+	}
+
+	out.Revision = &Revision{Ref: "origin/main", Commit: "0123456789abcdef0123456789abcdef01234567"}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("marshal output: %v", err)
+	}
+	if !strings.Contains(string(b), `"revision":{"ref":"origin/main","commit":"0123456789abcdef0123456789abcdef01234567"}`) {
+		t.Fatalf("unexpected revision JSON: %s", b)
+	}
+	var buf bytes.Buffer
+	PrintVerboseTable(&buf, out)
+	if !strings.Contains(buf.String(), "REVISION: origin/main (0123456789abcdef0123456789abcdef01234567)\n") {
+		t.Fatalf("table missing revision line:\n%s", buf.String())
+	}
+}
+
 func TestFromRun_SummaryAllPassed(t *testing.T) {
 	out := FromRun([]CheckStatus{
 		{Check: "manifest", Status: "pass"},
@@ -90,6 +175,43 @@ func TestFromRun_SummaryAllPassed(t *testing.T) {
 	}
 }
 
+func TestFromRun_SummaryWithErroredChecks(t *testing.T) {
+	cases := []struct {
+		statuses []string
+		want     string
+	}{
+		{[]string{"pass", "errored"}, "1 of 2 checks errored."},
+		{[]string{"fail", "errored", "pass"}, "1 of 3 checks failed, 1 errored."},
+	}
+	for _, tc := range cases {
+		var statuses []CheckStatus
+		for i, s := range tc.statuses {
+			statuses = append(statuses, CheckStatus{Check: string(rune('a' + i)), Status: s})
+		}
+		if got := FromRun(statuses, nil).Summary; got != tc.want {
+			t.Fatalf("summary for %v = %q, want %q", tc.statuses, got, tc.want)
+		}
+	}
+}
+
+func TestPrintVerboseTable_ShowsCheckError(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "readme_links", Status: "errored", Level: checks.LevelError, Error: "timed out after 1m0s"},
+	}, nil)
+	var buf bytes.Buffer
+	PrintVerboseTable(&buf, out)
+	if !strings.Contains(buf.String(), "errored") || !strings.Contains(buf.String(), "Error: timed out after 1m0s") {
+		t.Fatalf("errored check not rendered:\n%s", buf.String())
+	}
+	b, err := json.Marshal(out.Checks[0])
+	if err != nil {
+		t.Fatalf("marshal status: %v", err)
+	}
+	if !strings.Contains(string(b), `"status":"errored"`) || !strings.Contains(string(b), `"error":"timed out after 1m0s"`) {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+}
+
 func TestFromRun_SummaryWithFailures(t *testing.T) {
 	out := FromRun([]CheckStatus{
 		{Check: "manifest", Status: "pass"},
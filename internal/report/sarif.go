@@ -0,0 +1,247 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/hittegit/yardstick/internal/baseline"
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+// SARIF 2.1.0 output for code-scanning dashboards.
+//
+// Each registered check becomes a rule and each finding a result. Locations
+// are relative to the scan root via the SRCROOT base id, so uploads work no
+// matter where the repository was checked out.
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifRootID  = "SRCROOT"
+	toolInfoURI  = "https://github.com/hittegit/yardstick"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLoc `json:"originalUriBaseIds,omitempty"`
+	ColumnKind         string                      `json:"columnKind"`
+	Invocations        []sarifInvocation           `json:"invocations"`
+	Results            []sarifResult               `json:"results"`
+}
+
+// sarifInvocation records whether every check completed; errored checks
+// become tool execution notifications tied to their rule.
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level          string        `json:"level"`
+	Message        sarifMessage  `json:"message"`
+	AssociatedRule *sarifRuleRef `json:"associatedRule,omitempty"`
+}
+
+type sarifRuleRef struct {
+	ID    string `json:"id"`
+	Index *int   `json:"index,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 *sarifHelp         `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifHelp struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           *int               `json:"ruleIndex,omitempty"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+	Region           *sarifRegion     `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifArtifactLoc struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// WriteSARIF renders out as a SARIF 2.1.0 log. rules is the check registry,
+// root is the scan root used to relativize paths, and version identifies the
+// yardstick build in the tool metadata.
+func WriteSARIF(w io.Writer, out Output, rules []checks.Check, root, version string) error {
+	driver := sarifDriver{
+		Name:           "yardstick",
+		Version:        version,
+		InformationURI: toolInfoURI,
+		Rules:          make([]sarifRule, 0, len(rules)),
+	}
+	ruleIndex := make(map[string]int, len(rules))
+	for i, c := range rules {
+		ruleIndex[c.Key()] = i
+		rule := sarifRule{
+			ID:                   c.Key(),
+			Name:                 c.Key(),
+			ShortDescription:     sarifMessage{Text: c.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: "warning"},
+		}
+		if g, ok := checks.GuidanceForCheck(c.Key()); ok {
+			rule.Help = &sarifHelp{
+				Text:     "Why: " + g.WhyImportant + "\nFix: " + g.HowToResolve,
+				Markdown: "**Why it matters:** " + g.WhyImportant + "\n\n**How to resolve:** " + g.HowToResolve,
+			}
+		}
+		driver.Rules = append(driver.Rules, rule)
+	}
+
+	results := make([]sarifResult, 0, len(out.Findings))
+	for _, f := range out.Findings {
+		res := sarifResult{
+			RuleID:  f.Check,
+			Level:   sarifLevel(f.Level),
+			Message: sarifMessage{Text: f.Message},
+			PartialFingerprints: map[string]string{
+				"yardstick/v1": baseline.EntryFor(root, f).Fingerprint,
+			},
+		}
+		if i, ok := ruleIndex[f.Check]; ok {
+			res.RuleIndex = &i
+		}
+		// Repository-wide findings point at the root itself, which SARIF
+		// consumers cannot annotate, so they are reported without a location.
+		if rel := baseline.RelPath(root, f.Path); rel != "." && !filepath.IsAbs(filepath.FromSlash(rel)) {
+			res.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLoc{URI: (&url.URL{Path: rel}).String(), URIBaseID: sarifRootID},
+				Region:           sarifRegionFor(f),
+			}}}
+		}
+		if f.Suppressed {
+			res.Suppressions = []sarifSuppression{{Kind: "external", Justification: "Matched yardstick baseline"}}
+		}
+		results = append(results, res)
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+	for _, s := range out.Checks {
+		if s.Status != "errored" {
+			continue
+		}
+		invocation.ExecutionSuccessful = false
+		ref := &sarifRuleRef{ID: s.Check}
+		if i, ok := ruleIndex[s.Check]; ok {
+			ref.Index = &i
+		}
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+			Level:          "error",
+			Message:        sarifMessage{Text: "Check errored: " + s.Error},
+			AssociatedRule: ref,
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:               sarifTool{Driver: driver},
+			OriginalURIBaseIDs: map[string]sarifArtifactLoc{sarifRootID: {URI: fileURI(root)}},
+			ColumnKind:         "unicodeCodePoints",
+			Invocations:        []sarifInvocation{invocation},
+			Results:            results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifRegionFor returns the region for a finding with a known line, or nil.
+// Finding columns are code points, matching the run's columnKind.
+func sarifRegionFor(f checks.Finding) *sarifRegion {
+	if f.Line == 0 {
+		return nil
+	}
+	return &sarifRegion{StartLine: f.Line, StartColumn: f.Column, EndLine: f.EndLine, EndColumn: f.EndColumn}
+}
+
+// sarifLevel maps yardstick levels onto SARIF result levels.
+func sarifLevel(l checks.Level) string {
+	switch l {
+	case checks.LevelError:
+		return "error"
+	case checks.LevelWarn:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// fileURI converts a directory path to the file URI form SARIF expects for
+// base ids, including the trailing slash.
+func fileURI(dir string) string {
+	p := filepath.ToSlash(dir)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hittegit/yardstick/internal/checks"
+)
+
+type stubCheck struct{ key, desc string }
+
+func (s stubCheck) Key() string         { return s.key }
+func (s stubCheck) Description() string { return s.desc }
+func (s stubCheck) Run(ctx context.Context, root string, opts checks.Options) ([]checks.Finding, error) {
+	return nil, nil
+}
+
+func TestWriteSARIF_RulesAndResults(t *testing.T) {
+	out := FromFindings([]checks.Finding{
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/docs/READ ME.md", Message: "Missing section: ## CI"},
+		{Check: "license", Level: checks.LevelError, Path: "/repo/LICENSE", Message: "LICENSE missing", Suppressed: true},
+		{Check: "manifest", Level: checks.LevelInfo, Path: "/repo", Message: "No manifest"},
+	})
+	rules := []checks.Check{
+		stubCheck{key: "manifest", desc: "Detects manifests"},
+		stubCheck{key: "readme", desc: "Ensures README.md exists"},
+		stubCheck{key: "license", desc: "Ensures LICENSE exists"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, out, rules, "/repo", "v1.2.3"); err != nil {
+		t.Fatalf("write sarif: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+					Rules   []struct {
+						ID               string `json:"id"`
+						ShortDescription struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+						Help struct {
+							Text string `json:"text"`
+						} `json:"help"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			OriginalURIBaseIDs map[string]struct {
+				URI string `json:"uri"`
+			} `json:"originalUriBaseIds"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex *int   `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       string `json:"uri"`
+							URIBaseID string `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Suppressions []struct {
+					Kind string `json:"kind"`
+				} `json:"suppressions"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log envelope: %s", buf.String())
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "yardstick" || run.Tool.Driver.Version != "v1.2.3" {
+		t.Fatalf("unexpected driver: %+v", run.Tool.Driver)
+	}
+	if len(run.Tool.Driver.Rules) != 3 || run.Tool.Driver.Rules[1].ID != "readme" || run.Tool.Driver.Rules[1].ShortDescription.Text != "Ensures README.md exists" {
+		t.Fatalf("unexpected rules: %+v", run.Tool.Driver.Rules)
+	}
+	if run.Tool.Driver.Rules[1].Help.Text == "" {
+		t.Fatalf("expected guidance help text on readme rule")
+	}
+	if run.OriginalURIBaseIDs["SRCROOT"].URI != "file:///repo/" {
+		t.Fatalf("unexpected base uri: %+v", run.OriginalURIBaseIDs)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	readme := run.Results[0]
+	if readme.Level != "warning" || readme.RuleIndex == nil || *readme.RuleIndex != 1 {
+		t.Fatalf("unexpected readme result: %+v", readme)
+	}
+	loc := readme.Locations[0].PhysicalLocation.ArtifactLocation
+	if loc.URI != "docs/READ%20ME.md" || loc.URIBaseID != "SRCROOT" {
+		t.Fatalf("unexpected artifact location: %+v", loc)
+	}
+	if license := run.Results[1]; license.Level != "error" || len(license.Suppressions) != 1 {
+		t.Fatalf("unexpected license result: %+v", license)
+	}
+	if manifest := run.Results[2]; manifest.Level != "note" || len(manifest.Locations) != 0 {
+		t.Fatalf("root-level finding should be a note without location: %+v", manifest)
+	}
+}
+
+func TestWriteSARIF_RegionFromFindingPosition(t *testing.T) {
+	out := FromFindings([]checks.Finding{
+		{Check: "readme_links", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "broken", Line: 4, Column: 9, EndLine: 4, EndColumn: 22},
+		{Check: "readme", Level: checks.LevelWarn, Path: "/repo/README.md", Message: "whole file"},
+	})
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, out, nil, "/repo", "dev"); err != nil {
+		t.Fatalf("write sarif: %v", err)
+	}
+
+	var log struct {
+		Runs []struct {
+			ColumnKind string `json:"columnKind"`
+			Results    []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						Region *sarifRegion `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	run := log.Runs[0]
+	if run.ColumnKind != "unicodeCodePoints" {
+		t.Fatalf("columnKind = %q", run.ColumnKind)
+	}
+	want := sarifRegion{StartLine: 4, StartColumn: 9, EndLine: 4, EndColumn: 22}
+	if r := run.Results[0].Locations[0].PhysicalLocation.Region; r == nil || *r != want {
+		t.Fatalf("region = %+v, want %+v", r, want)
+	}
+	if r := run.Results[1].Locations[0].PhysicalLocation.Region; r != nil {
+		t.Fatalf("whole-file finding should have no region, got %+v", r)
+	}
+}
+
+func TestWriteSARIF_ErroredChecksMarkInvocationFailed(t *testing.T) {
+	out := FromRun([]CheckStatus{
+		{Check: "readme_links", Status: "errored", Level: checks.LevelError, Error: "timed out after 1m0s"},
+	}, nil)
+	rules := []checks.Check{stubCheck{key: "readme", desc: "d"}, stubCheck{key: "readme_links", desc: "d"}}
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, out, rules, "/repo", "dev"); err != nil {
+		t.Fatalf("write sarif: %v", err)
+	}
+	var log struct {
+		Runs []struct {
+			Invocations []sarifInvocation `json:"invocations"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	inv := log.Runs[0].Invocations[0]
+	if inv.ExecutionSuccessful || len(inv.ToolExecutionNotifications) != 1 {
+		t.Fatalf("unexpected invocation: %+v", inv)
+	}
+	n := inv.ToolExecutionNotifications[0]
+	if n.AssociatedRule.ID != "readme_links" || n.AssociatedRule.Index == nil || *n.AssociatedRule.Index != 1 {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+}
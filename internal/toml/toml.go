@@ -0,0 +1,678 @@
+// Package toml decodes TOML documents such as Cargo.toml and pyproject.toml
+// for checks that need more than a file's existence.
+//
+// It implements the TOML 1.0 grammar checks rely on: tables, arrays of
+// tables, dotted and quoted keys, inline tables, multi-line arrays, and all
+// four string forms. Dates and times are kept as their source text, since no
+// check compares them. Malformed input fails with a line-numbered *Error
+// instead of being partially decoded.
+package toml
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table is a decoded TOML table. Values are string, int64, float64, bool,
+// []any, or Table.
+type Table map[string]any
+
+// Get returns the value at a dotted key path, descending through nested
+// tables.
+func (t Table) Get(path ...string) (any, bool) {
+	var v any = t
+	for _, k := range path {
+		tab, ok := v.(Table)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = tab[k]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// String returns the string at path, reporting false when it is absent or
+// not a string.
+func (t Table) String(path ...string) (string, bool) {
+	v, _ := t.Get(path...)
+	s, ok := v.(string)
+	return s, ok
+}
+
+// Table returns the table at path, reporting false when it is absent or
+// not a table.
+func (t Table) Table(path ...string) (Table, bool) {
+	v, _ := t.Get(path...)
+	tab, ok := v.(Table)
+	return tab, ok
+}
+
+// Array returns the array at path, reporting false when it is absent or
+// not an array.
+func (t Table) Array(path ...string) ([]any, bool) {
+	v, _ := t.Get(path...)
+	a, ok := v.([]any)
+	return a, ok
+}
+
+// Document is a parsed TOML file.
+type Document struct {
+	// Root is the top-level table.
+	Root Table
+
+	lines map[string]int
+}
+
+// Line returns the 1-based line where the key, table header, or array
+// element at path was defined, or 0 when it was not. Array elements,
+// including those of arrays of tables, are addressed by their index, for
+// example Line("bin", "0", "name").
+func (d *Document) Line(path ...string) int {
+	return d.lines[strings.Join(path, "\x00")]
+}
+
+// Error reports a syntax error at a specific line.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// Parse decodes a TOML document.
+func Parse(data []byte) (*Document, error) {
+	src := string(data)
+	if !utf8.ValidString(src) {
+		return nil, &Error{Line: 1, Msg: "document is not valid UTF-8"}
+	}
+	p := &parser{
+		src:     strings.TrimPrefix(src, "\ufeff"),
+		line:    1,
+		doc:     &Document{Root: Table{}, lines: map[string]int{}},
+		defined: map[string]bool{},
+		sealed:  map[string]bool{},
+	}
+	p.cur = p.doc.Root
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.doc, nil
+}
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+	doc  *Document
+
+	// cur is the table the latest header opened, at curPath.
+	cur     Table
+	curPath []string
+	// defined records tables created by a header or by dotted keys, which
+	// TOML forbids defining twice. Inline tables and arrays are sealed.
+	defined map[string]bool
+	sealed  map[string]bool
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &Error{Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) advance(n int) {
+	p.line += strings.Count(p.src[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipSpace skips spaces and tabs on the current line.
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to, not including, the line break.
+func (p *parser) skipComment() {
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+}
+
+// skipBlank skips whitespace, line breaks, and comments.
+func (p *parser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "\r\n"):
+			p.advance(2)
+		case p.peek() == '\n':
+			p.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+// endLine expects only whitespace and a comment before the next line.
+func (p *parser) endLine() error {
+	p.skipSpace()
+	p.skipComment()
+	switch {
+	case p.eof():
+	case strings.HasPrefix(p.src[p.pos:], "\r\n"):
+		p.advance(2)
+	case p.peek() == '\n':
+		p.advance(1)
+	default:
+		return p.errorf("unexpected %q after value", p.peek())
+	}
+	return nil
+}
+
+func (p *parser) parse() error {
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+		var err error
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "[["):
+			err = p.arrayTableHeader()
+		case p.peek() == '[':
+			err = p.tableHeader()
+		default:
+			err = p.keyValue(p.cur, p.curPath)
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+func pathKey(path []string) string { return strings.Join(path, "\x00") }
+
+// descend walks from the root through keys, creating implicit tables and
+// entering the last element of arrays of tables. It returns the table at
+// keys and its address path.
+func (p *parser) descend(keys []string) (Table, []string, error) {
+	t := p.doc.Root
+	var path []string
+	for _, k := range keys {
+		path = append(path, k)
+		switch v := t[k].(type) {
+		case nil:
+			next := Table{}
+			t[k] = next
+			t = next
+		case Table:
+			if p.sealed[pathKey(path)] {
+				return nil, nil, p.errorf("cannot extend inline table %s", strings.Join(keys, "."))
+			}
+			t = v
+		case []any:
+			if !p.sealed[pathKey(path)] && len(v) > 0 {
+				if last, ok := v[len(v)-1].(Table); ok {
+					path = append(path, strconv.Itoa(len(v)-1))
+					t = last
+					continue
+				}
+			}
+			return nil, nil, p.errorf("key %s is an array, not a table", strings.Join(keys, "."))
+		default:
+			return nil, nil, p.errorf("key %s is already defined as a value", strings.Join(keys, "."))
+		}
+	}
+	return t, path, nil
+}
+
+func (p *parser) tableHeader() error {
+	line := p.line
+	p.pos++
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != ']' {
+		return p.errorf("expected ] to close table header")
+	}
+	p.pos++
+	t, path, err := p.descend(keys)
+	if err != nil {
+		return err
+	}
+	k := pathKey(path)
+	if p.defined[k] {
+		return &Error{Line: line, Msg: fmt.Sprintf("table %s is defined twice", strings.Join(keys, "."))}
+	}
+	p.defined[k] = true
+	p.doc.lines[k] = line
+	p.cur, p.curPath = t, path
+	return nil
+}
+
+func (p *parser) arrayTableHeader() error {
+	line := p.line
+	p.pos += 2
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if !strings.HasPrefix(p.src[p.pos:], "]]") {
+		return p.errorf("expected ]] to close array of tables header")
+	}
+	p.pos += 2
+	parent, path, err := p.descend(keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	path = append(path, last)
+	var arr []any
+	switch v := parent[last].(type) {
+	case nil:
+	case []any:
+		if p.sealed[pathKey(path)] {
+			return p.errorf("cannot append to static array %s", strings.Join(keys, "."))
+		}
+		arr = v
+	default:
+		return p.errorf("key %s is already defined as a value", strings.Join(keys, "."))
+	}
+	t := Table{}
+	parent[last] = append(arr, t)
+	p.doc.lines[pathKey(path)] = line
+	path = append(path, strconv.Itoa(len(arr)))
+	p.doc.lines[pathKey(path)] = line
+	p.defined[pathKey(path)] = true
+	p.cur, p.curPath = t, path
+	return nil
+}
+
+// keyValue parses "key = value" into t, whose address is path.
+func (p *parser) keyValue(t Table, path []string) error {
+	line := p.line
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != '=' {
+		return p.errorf("expected = after key %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace()
+	full := append(append([]string{}, path...), keys...)
+	val, err := p.value(full)
+	if err != nil {
+		return err
+	}
+	for i, k := range keys[:len(keys)-1] {
+		sub := append(append([]string{}, path...), keys[:i+1]...)
+		switch v := t[k].(type) {
+		case nil:
+			next := Table{}
+			t[k] = next
+			t = next
+			p.defined[pathKey(sub)] = true
+		case Table:
+			if p.sealed[pathKey(sub)] {
+				return &Error{Line: line, Msg: fmt.Sprintf("cannot extend inline table %s", strings.Join(keys[:i+1], "."))}
+			}
+			t = v
+		default:
+			return &Error{Line: line, Msg: fmt.Sprintf("key %s is already defined as a value", strings.Join(keys[:i+1], "."))}
+		}
+	}
+	last := keys[len(keys)-1]
+	if _, dup := t[last]; dup {
+		return &Error{Line: line, Msg: fmt.Sprintf("key %s is defined twice", strings.Join(keys, "."))}
+	}
+	t[last] = val
+	p.doc.lines[pathKey(full)] = line
+	switch val.(type) {
+	case Table, []any:
+		p.sealed[pathKey(full)] = true
+	}
+	return nil
+}
+
+// key parses a possibly dotted key of bare and quoted parts.
+func (p *parser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		var k string
+		switch c := p.peek(); {
+		case c == '"':
+			s, err := p.basicString()
+			if err != nil {
+				return nil, err
+			}
+			k = s
+		case c == '\'':
+			s, err := p.literalString()
+			if err != nil {
+				return nil, err
+			}
+			k = s
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected a key")
+			}
+			k = p.src[start:p.pos]
+		}
+		keys = append(keys, k)
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value parses any value. path addresses it, for recording the lines of
+// keys inside inline tables.
+func (p *parser) value(path []string) (any, error) {
+	rest := p.src[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return p.multilineString(`"""`)
+	case strings.HasPrefix(rest, "'''"):
+		return p.multilineString("'''")
+	case strings.HasPrefix(rest, `"`):
+		return p.basicString()
+	case strings.HasPrefix(rest, "'"):
+		return p.literalString()
+	case strings.HasPrefix(rest, "["):
+		return p.array(path)
+	case strings.HasPrefix(rest, "{"):
+		return p.inlineTable(path)
+	case strings.HasPrefix(rest, "true") && !startsBare(rest[4:]):
+		p.pos += 4
+		return true, nil
+	case strings.HasPrefix(rest, "false") && !startsBare(rest[5:]):
+		p.pos += 5
+		return false, nil
+	}
+	return p.scalar()
+}
+
+func startsBare(s string) bool { return s != "" && isBareKeyChar(s[0]) }
+
+func (p *parser) array(path []string) ([]any, error) {
+	p.pos++
+	arr := []any{}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return arr, nil
+		}
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		elem := append(append([]string{}, path...), strconv.Itoa(len(arr)))
+		p.doc.lines[pathKey(elem)] = p.line
+		v, err := p.value(elem)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected , or ] in array")
+		}
+	}
+}
+
+func (p *parser) inlineTable(path []string) (Table, error) {
+	p.pos++
+	t := Table{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return t, nil
+	}
+	for {
+		if err := p.keyValue(t, path); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return t, nil
+		default:
+			return nil, p.errorf("expected , or } in inline table")
+		}
+	}
+}
+
+func (p *parser) literalString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.src[p.pos:], "'\n")
+	if end < 0 || p.src[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *parser) basicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) multilineString(delim string) (string, error) {
+	p.pos += 3
+	// A line break right after the opening delimiter is trimmed.
+	if strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.advance(2)
+	} else if p.peek() == '\n' {
+		p.advance(1)
+	}
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			// Up to two quotes may directly precede the closing delimiter.
+			n := 3
+			for n < 5 && p.pos+n < len(p.src) && p.src[p.pos+n] == delim[0] {
+				n++
+			}
+			b.WriteString(p.src[p.pos+3 : p.pos+n])
+			p.pos += n
+			return b.String(), nil
+		}
+		c := p.peek()
+		if c == '\\' && delim == `"""` {
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.advance(1)
+	}
+}
+
+// escape decodes one backslash escape into b. A backslash ending a line in
+// a multi-line string trims the following whitespace.
+func (p *parser) escape(b *strings.Builder) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated escape")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.src) {
+			return p.errorf("short unicode escape")
+		}
+		r, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return p.errorf("invalid unicode escape %q", p.src[p.pos:p.pos+n])
+		}
+		b.WriteRune(rune(r))
+		p.pos += n
+	case ' ', '\t', '\r', '\n':
+		p.pos--
+		start := p.pos
+		for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+			p.pos++
+		}
+		if !strings.Contains(p.src[start:p.pos], "\n") {
+			return p.errorf("invalid escape")
+		}
+		p.line += strings.Count(p.src[start:p.pos], "\n")
+	default:
+		return p.errorf("invalid escape \\%c", c)
+	}
+	return nil
+}
+
+// scalar parses numbers, dates, and times.
+func (p *parser) scalar() (any, error) {
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.peek()) < 0 {
+		p.pos++
+	}
+	// A local date may be followed by a space and a time.
+	if p.pos-start == 10 && isDate(p.src[start:p.pos]) &&
+		p.peek() == ' ' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1]) {
+		p.pos++
+		for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.peek()) < 0 {
+			p.pos++
+		}
+	}
+	tok := p.src[start:p.pos]
+	if tok == "" {
+		return nil, p.errorf("expected a value")
+	}
+	if isDate(tok) || len(tok) >= 8 && isDigit(tok[0]) && isDigit(tok[1]) && tok[2] == ':' {
+		return tok, nil
+	}
+	switch strings.TrimLeft(tok, "+-") {
+	case "inf":
+		if tok[0] == '-' {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+	clean := strings.ReplaceAll(tok, "_", "")
+	if strings.HasPrefix(tok, "0x") || strings.HasPrefix(tok, "0o") || strings.HasPrefix(tok, "0b") {
+		if n, err := strconv.ParseInt(clean, 0, 64); err == nil {
+			return n, nil
+		}
+		return nil, p.errorf("invalid number %q", tok)
+	}
+	if !strings.ContainsAny(tok, ".eE") {
+		digits := strings.TrimLeft(clean, "+-")
+		if len(digits) > 1 && digits[0] == '0' {
+			return nil, p.errorf("leading zeros are not allowed in %q", tok)
+		}
+		if n, err := strconv.ParseInt(clean, 10, 64); err == nil {
+			return n, nil
+		}
+		return nil, p.errorf("invalid value %q", tok)
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, nil
+	}
+	return nil, p.errorf("invalid value %q", tok)
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// isDate reports whether s starts with a YYYY-MM-DD date.
+func isDate(s string) bool {
+	if len(s) < 10 || s[4] != '-' || s[7] != '-' {
+		return false
+	}
+	for _, i := range []int{0, 1, 2, 3, 5, 6, 8, 9} {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package toml
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParse_Document(t *testing.T) {
+	src := `# Cargo-style manifest
+title = "demo"   # trailing comment
+'quoted key' = 'C:\raw'
+"esc" = "tab\tquote\" \u00e9"
+int = +1_000
+hex = 0xff
+neg = -3.5e2
+inf = -inf
+on = true
+date = 1979-05-27 07:32:00Z
+list = [
+  1,
+  2, # comment inside
+]
+nested = [["a"], ["b", "c"]]
+multi = """
+first \
+   second"""
+lit = '''
+raw \n'''
+point = { x = 1, y.z = 2 }
+
+[package]
+name = "app"
+metadata.docs.all = true
+
+[workspace]
+members = ["crates/*"]
+
+[[bin]]
+name = "one"
+
+[[bin]]
+name = "two"
+
+[bin.extra]
+k = "v"
+
+[a.b.c]
+[a]
+d = 1
+`
+	doc, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	r := doc.Root
+	want := map[string]any{
+		"title":      "demo",
+		"quoted key": `C:\raw`,
+		"esc":        "tab\tquote\" é",
+		"int":        int64(1000),
+		"hex":        int64(255),
+		"neg":        -350.0,
+		"on":         true,
+		"date":       "1979-05-27 07:32:00Z",
+		"multi":      "first second",
+		"lit":        `raw \n`,
+	}
+	for k, v := range want {
+		if r[k] != v {
+			t.Errorf("%s = %#v, want %#v", k, r[k], v)
+		}
+	}
+	if f, _ := r["inf"].(float64); !math.IsInf(f, -1) {
+		t.Errorf("inf = %#v", r["inf"])
+	}
+	if !reflect.DeepEqual(r["list"], []any{int64(1), int64(2)}) {
+		t.Errorf("list = %#v", r["list"])
+	}
+	if !reflect.DeepEqual(r["nested"], []any{[]any{"a"}, []any{"b", "c"}}) {
+		t.Errorf("nested = %#v", r["nested"])
+	}
+	if !reflect.DeepEqual(r["point"], Table{"x": int64(1), "y": Table{"z": int64(2)}}) {
+		t.Errorf("point = %#v", r["point"])
+	}
+	if s, _ := r.String("package", "name"); s != "app" {
+		t.Errorf("package.name = %q", s)
+	}
+	if v, _ := r.Get("package", "metadata", "docs", "all"); v != true {
+		t.Errorf("package.metadata.docs.all = %#v", v)
+	}
+	if m, _ := r.Array("workspace", "members"); !reflect.DeepEqual(m, []any{"crates/*"}) {
+		t.Errorf("workspace.members = %#v", m)
+	}
+	bins, _ := r.Array("bin")
+	if len(bins) != 2 || bins[1].(Table)["name"] != "two" || bins[1].(Table)["extra"].(Table)["k"] != "v" {
+		t.Errorf("bin = %#v", bins)
+	}
+	if v, _ := r.Get("a", "d"); v != int64(1) {
+		t.Errorf("a.d = %#v", v)
+	}
+	if _, ok := r.Table("a", "b", "c"); !ok {
+		t.Errorf("a.b.c missing")
+	}
+
+	for _, tc := range []struct {
+		path []string
+		line int
+	}{
+		{[]string{"title"}, 2},
+		{[]string{"list", "1"}, 13},
+		{[]string{"point", "x"}, 21},
+		{[]string{"package"}, 23},
+		{[]string{"package", "name"}, 24},
+		{[]string{"workspace", "members"}, 28},
+		{[]string{"bin", "1", "name"}, 34},
+		{[]string{"bin", "1", "extra", "k"}, 37},
+		{[]string{"missing"}, 0},
+	} {
+		if got := doc.Line(tc.path...); got != tc.line {
+			t.Errorf("Line(%v) = %d, want %d", tc.path, got, tc.line)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		src  string
+		line int
+	}{
+		{"a = 1\na = 2\n", 2},
+		{"[t]\n[t]\n", 2},
+		{"a = \"open\n", 1},
+		{"a = [1, 2\n", 2},
+		{"x = 1\ny = 01\n", 2},
+		{"a = 1 b = 2\n", 1},
+		{"a = {b = 1}\n[a]\n", 2},
+		{"a = [1]\n[[a]]\n", 2},
+		{"a.b = 1\n[a.b]\n", 2},
+		{"name\n", 1},
+		{"a = \"\\q\"\n", 1},
+	} {
+		_, err := Parse([]byte(tc.src))
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("Parse(%q) = %v, want *Error", tc.src, err)
+			continue
+		}
+		if e.Line != tc.line {
+			t.Errorf("Parse(%q) error on line %d, want %d (%v)", tc.src, e.Line, tc.line, e)
+		}
+	}
+}
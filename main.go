@@ -6,26 +6,49 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/hittegit/yardstick/internal/baseline"
 	"github.com/hittegit/yardstick/internal/checks"
+	"github.com/hittegit/yardstick/internal/config"
+	"github.com/hittegit/yardstick/internal/git"
+	"github.com/hittegit/yardstick/internal/repo"
 	"github.com/hittegit/yardstick/internal/report"
 )
 
 // Define command-line flags for configuration.
 // These control how yardstick runs and what output format it uses.
 var (
-	flagFormat  = flag.String("format", "table", "output format: table or json")
-	flagPath    = flag.String("path", ".", "path to scan")
+	flagFormat  = flag.String("format", "table", "output format: table, json, sarif, junit, github, markdown, or html")
+	flagPath    = flag.String("path", ".", "directory or .zip, .tar.gz, or .tgz archive to scan")
 	flagStrict  = flag.Bool("strict", false, "nonzero exit if any warn-level finding exists")
 	flagOnly    = flag.String("only", "", "comma-separated list of checks to run, empty means all")
 	flagList    = flag.Bool("list", false, "list available checks")
 	flagVersion = flag.Bool("version", false, "print version and exit")
+	flagConfig  = flag.String("config", "", "config file path, empty discovers .yardstick.yml or .yardstick.json in -path")
+	flagJobs    = flag.Int("jobs", runtime.NumCPU(), "number of checks to run concurrently")
+	flagRev     = flag.String("rev", "", "git revision to scan from the object store at -path instead of the working tree, e.g. origin/main or v1.2.0")
+	flagTimeout = flag.Duration("timeout", time.Minute, "per-check timeout, 0 disables; checks.<key>.timeout in config overrides it")
+
+	flagBaseline       = flag.String("baseline", "", "baseline file of accepted findings; matching findings are suppressed")
+	flagUpdateBaseline = flag.Bool("update-baseline", false, "record current findings into the -baseline file")
 )
 
+// registry returns the checks run can select from. Tests replace it to
+// exercise the runner with stub checks.
+var registry = checks.All
+
+// formats lists the accepted -format values.
+var formats = []string{"table", "json", "sarif", "junit", "github", "markdown", "html"}
+
 // Build-time variables injected via -ldflags at release time.
 // Default values are for local development.
 var (
@@ -43,17 +66,40 @@ func main() {
 	}
 	if err := run(context.Background()); err != nil {
 		fmt.Fprintf(os.Stderr, "yardstick error: %v\n", err)
-		os.Exit(2)
+		os.Exit(exitCode(err))
+	}
+}
+
+// errChecksErrored is returned by run when at least one check could not
+// complete. The report is still printed for every other check.
+var errChecksErrored = errors.New("checks errored")
+
+// exitCode maps a run error to the process exit code: 3 when checks errored,
+// so CI can tell an incomplete report from policy violations and usage
+// errors, which exit 2.
+func exitCode(err error) int {
+	if errors.Is(err, errChecksErrored) {
+		return 3
 	}
+	return 2
 }
 
 // run performs the main logic of yardstick, executing checks and printing results.
 func run(ctx context.Context) error {
-	if *flagFormat != "table" && *flagFormat != "json" {
-		return fmt.Errorf("invalid -format %q, expected table or json", *flagFormat)
+	if !slices.Contains(formats, *flagFormat) {
+		return fmt.Errorf("invalid -format %q, expected one of: %s", *flagFormat, strings.Join(formats, ", "))
+	}
+	if *flagUpdateBaseline && *flagBaseline == "" {
+		return errors.New("-update-baseline requires -baseline")
+	}
+	if *flagJobs < 1 {
+		return fmt.Errorf("invalid -jobs %d, expected at least 1", *flagJobs)
+	}
+	if *flagTimeout < 0 {
+		return fmt.Errorf("invalid -timeout %s, expected 0 or a positive duration", *flagTimeout)
 	}
 
-	allChecks := checks.All()
+	allChecks := registry()
 	available := make(map[string]struct{}, len(allChecks))
 	for _, c := range allChecks {
 		available[c.Key()] = struct{}{}
@@ -73,6 +119,31 @@ func run(ctx context.Context) error {
 		return err
 	}
 
+	// Index the tree once so checks query it instead of re-statting the
+	// disk, however many of them look at the same paths. Archives and git
+	// revisions are read the same way, so checks never know where the tree
+	// came from.
+	idx, rev, release, err := openTree(ctx, root)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Load repository policy. Flags given on the command line always win
+	// over config defaults.
+	cfg, err := loadConfig(idx, allChecks)
+	if err != nil {
+		return err
+	}
+	format := *flagFormat
+	if cfg != nil && cfg.Format != "" && !flagWasSet("format") {
+		format = cfg.Format
+	}
+	strict := *flagStrict
+	if cfg != nil && cfg.Strict != nil && !flagWasSet("strict") {
+		strict = *cfg.Strict
+	}
+
 	// Parse the comma-separated list of specific checks to run (if provided).
 	var sel map[string]struct{}
 	if *flagOnly != "" {
@@ -94,30 +165,75 @@ func run(ctx context.Context) error {
 		}
 	}
 
-	// Run all registered checks (or a subset if specified).
-	var findings []checks.Finding
-	var checkStatuses []report.CheckStatus
+	// Select all registered checks (or a subset if specified).
+	var ran []checks.Check
 	for _, c := range allChecks {
-		// Skip any checks not listed in the --only flag.
+		// Skip any checks not listed in the --only flag. An explicit -only
+		// selection takes precedence over checks disabled in the config.
 		if sel != nil {
 			if _, ok := sel[c.Key()]; !ok {
 				continue
 			}
+		} else if !cfg.Enabled(c.Key()) {
+			continue
+		}
+		ran = append(ran, c)
+	}
+
+	// Run the selected checks concurrently. Results come back in registry
+	// order, so findings and statuses are deterministic regardless of -jobs.
+	// A check that errors or times out is reported as errored on its own
+	// instead of aborting the run and hiding every other result.
+	var findings []checks.Finding
+	var completed []checks.Check
+	errored := make(map[string]string)
+	for i, r := range runChecks(ctx, idx, ran, cfg, *flagJobs, *flagTimeout) {
+		c := ran[i]
+		switch {
+		case r.timedOut:
+			errored[c.Key()] = fmt.Sprintf("timed out after %s", r.timeout)
+		case r.err != nil:
+			// The whole run was cancelled, not just this check.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			errored[c.Key()] = r.err.Error()
+		default:
+			if level, ok := cfg.Level(c.Key()); ok {
+				overrideLevel(r.findings, level)
+			}
+			findings = append(findings, r.findings...)
+			completed = append(completed, c)
 		}
+	}
+
+	// Suppress findings recorded in the baseline before computing statuses,
+	// so accepted findings neither fail checks nor the exit policy. Errored
+	// checks are left out so their baseline entries are neither reported
+	// stale nor dropped by -update-baseline.
+	baselineStatus, err := applyBaseline(root, findings, completed)
+	if err != nil {
+		return err
+	}
 
-		// Execute each check; yardstick is read-only so AutoFix is ignored.
-		fs, err := c.Run(ctx, root, checks.Options{AutoFix: false})
-		if err != nil {
-			return fmt.Errorf("check %s: %w", c.Key(), err)
+	checkStatuses := make([]report.CheckStatus, 0, len(ran))
+	var erroredKeys []string
+	for _, c := range ran {
+		if msg, ok := errored[c.Key()]; ok {
+			checkStatuses = append(checkStatuses, erroredStatus(c, msg))
+			erroredKeys = append(erroredKeys, c.Key())
+			continue
 		}
-		findings = append(findings, fs...)
-		checkStatuses = append(checkStatuses, statusForCheck(c, fs))
+		checkStatuses = append(checkStatuses, statusForCheck(c, findingsFor(findings, c.Key())))
 	}
 
 	out := report.FromRun(checkStatuses, findings)
+	out.Baseline = baselineStatus
+	out.Ecosystems = checks.DetectEcosystems(idx)
+	out.Revision = rev
 
 	// Render the report in the requested format.
-	switch *flagFormat {
+	switch format {
 	case "json":
 		// Machine-readable output for CI pipelines.
 		enc := json.NewEncoder(os.Stdout)
@@ -125,6 +241,34 @@ func run(ctx context.Context) error {
 		if err := enc.Encode(out); err != nil {
 			return err
 		}
+	case "sarif":
+		// SARIF 2.1.0 for code-scanning dashboards; every registered check is a rule.
+		if err := report.WriteSARIF(os.Stdout, out, allChecks, root, buildVersion); err != nil {
+			return err
+		}
+	case "junit":
+		// JUnit XML so CI systems render each check as a test case.
+		if err := report.WriteJUnit(os.Stdout, out); err != nil {
+			return err
+		}
+	case "github":
+		// Workflow-command annotations plus a Markdown job summary.
+		if err := report.WriteGitHubAnnotations(os.Stdout, out, root); err != nil {
+			return err
+		}
+		if err := writeStepSummary(out, root); err != nil {
+			return err
+		}
+	case "markdown":
+		// Markdown for pull request comments.
+		if err := report.WriteMarkdown(os.Stdout, out, root); err != nil {
+			return err
+		}
+	case "html":
+		// Single self-contained page to publish as a CI artifact.
+		if err := report.WriteHTML(os.Stdout, out, root); err != nil {
+			return err
+		}
 	case "table":
 		// Human-readable table format with per-check status and guidance.
 		report.PrintVerboseTable(os.Stdout, out)
@@ -133,6 +277,9 @@ func run(ctx context.Context) error {
 	// Evaluate whether any errors or warnings should cause a nonzero exit.
 	var hasError, hasWarn bool
 	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
 		if f.Level == checks.LevelError {
 			hasError = true
 		}
@@ -142,20 +289,234 @@ func run(ctx context.Context) error {
 	}
 
 	// Exit code logic for CI integration.
+	// - Errored checks fail with their own exit code; the report is incomplete.
 	// - Errors always fail.
 	// - Warnings fail only when --strict is enabled.
-	if hasError || (*flagStrict && hasWarn) {
+	if len(erroredKeys) > 0 {
+		return fmt.Errorf("%w: %s", errChecksErrored, strings.Join(erroredKeys, ", "))
+	}
+	if hasError || (strict && hasWarn) {
 		return errors.New("policy violations found")
 	}
 	return nil
 }
 
-func statusForCheck(c checks.Check, fs []checks.Finding) report.CheckStatus {
+// checkResult is the outcome of running a single check.
+type checkResult struct {
+	findings []checks.Finding
+	err      error
+	timedOut bool
+	timeout  time.Duration
+}
+
+// runChecks executes cs on up to jobs workers and returns their results in
+// the same order as cs, so output never depends on which check finished
+// first.
+func runChecks(ctx context.Context, idx *repo.Index, cs []checks.Check, cfg *config.Config, jobs int, timeout time.Duration) []checkResult {
+	results := make([]checkResult, len(cs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(jobs, len(cs)) {
+		wg.Go(func() {
+			for i := range next {
+				results[i] = runCheck(ctx, idx, cs[i], cfg, timeout)
+			}
+		})
+	}
+	for i := range cs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// runCheck runs c under its own deadline. Checks that do not watch ctx are
+// abandoned once the deadline passes; they are read-only, so letting them
+// finish in the background is harmless.
+func runCheck(ctx context.Context, idx *repo.Index, c checks.Check, cfg *config.Config, timeout time.Duration) checkResult {
+	if d, ok := cfg.Timeout(c.Key()); ok {
+		timeout = d
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan checkResult, 1)
+	go func() {
+		// Yardstick is read-only, so AutoFix is always off.
+		fs, err := c.Run(ctx, idx.Root(), checks.Options{AutoFix: false, Settings: cfg.Settings(c.Key()), FS: idx})
+		done <- checkResult{findings: fs, err: err}
+	}()
+
+	var r checkResult
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		r.err = ctx.Err()
+	}
+	if r.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return checkResult{timedOut: true, timeout: timeout}
+	}
+	return r
+}
+
+// writeStepSummary appends the Markdown report to the file named by
+// $GITHUB_STEP_SUMMARY. Outside GitHub Actions the variable is unset and
+// nothing is written.
+func writeStepSummary(out report.Output, root string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	// #nosec G302 G304 -- the runner provides this file for job summaries.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open step summary: %w", err)
+	}
+	if err := report.WriteStepSummary(f, out, root); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write step summary: %w", err)
+	}
+	return f.Close()
+}
+
+// openTree indexes the tree to scan: the -rev commit read from the git
+// object store at root, or root itself. The revision is nil unless -rev
+// was given. release closes the object store once the run is done with the
+// index, which reads file contents lazily.
+func openTree(ctx context.Context, root string) (idx *repo.Index, rev *report.Revision, release func(), err error) {
+	if *flagRev == "" {
+		if idx, err = repo.Open(ctx, root); err != nil {
+			return nil, nil, nil, fmt.Errorf("index %s: %w", root, err)
+		}
+		return idx, nil, func() {}, nil
+	}
+
+	gr, err := git.Open(root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	release = func() { _ = gr.Close() }
+	commit, err := gr.Resolve(*flagRev)
+	if err != nil {
+		release()
+		return nil, nil, nil, err
+	}
+	tree, err := gr.TreeFS(commit)
+	if err == nil {
+		idx, err = repo.FromFS(ctx, tree, root)
+	}
+	if err != nil {
+		release()
+		return nil, nil, nil, fmt.Errorf("index %s at %s: %w", root, *flagRev, err)
+	}
+	return idx, &report.Revision{Ref: *flagRev, Commit: commit.String()}, release, nil
+}
+
+// loadConfig loads the -config file, or discovers one at the root of the
+// scanned tree, which may be an archive. A nil config means no file was
+// found and defaults apply.
+func loadConfig(idx *repo.Index, all []checks.Check) (*config.Config, error) {
+	if *flagConfig != "" {
+		return config.Load(*flagConfig, all, formats)
+	}
+	name, err := config.DiscoverFS(idx, idx.Root())
+	if err != nil || name == "" {
+		return nil, err
+	}
+	b, err := fs.ReadFile(idx, name)
+	if err != nil {
+		return nil, err
+	}
+	return config.Parse(filepath.Join(idx.Root(), filepath.FromSlash(name)), b, all, formats)
+}
+
+// flagWasSet reports whether a flag was given explicitly on the command line.
+func flagWasSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// overrideLevel rewrites actionable findings to the configured level. Info
+// findings stay informational so detection notes never start failing CI.
+func overrideLevel(fs []checks.Finding, level checks.Level) {
+	for i := range fs {
+		if fs[i].Level != checks.LevelInfo {
+			fs[i].Level = level
+		}
+	}
+}
+
+// applyBaseline records or applies the -baseline file. With -update-baseline
+// the current findings are written first, so the run reports them all as
+// suppressed. It returns nil when no baseline was requested.
+func applyBaseline(root string, findings []checks.Finding, ran []checks.Check) (*report.BaselineStatus, error) {
+	if *flagBaseline == "" {
+		return nil, nil
+	}
+	ranKeys := make(map[string]struct{}, len(ran))
+	for _, c := range ran {
+		ranKeys[c.Key()] = struct{}{}
+	}
+
+	var b *baseline.File
+	if *flagUpdateBaseline {
+		prev, err := baseline.Load(*flagBaseline)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		next := baseline.Merge(prev, baseline.FromFindings(root, findings), ranKeys)
+		if err := baseline.Write(*flagBaseline, next); err != nil {
+			return nil, fmt.Errorf("write baseline: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "yardstick: recorded %d findings in %s\n", len(next.Findings), *flagBaseline)
+		b = &next
+	} else {
+		var err error
+		if b, err = baseline.Load(*flagBaseline); err != nil {
+			return nil, err
+		}
+	}
+
+	_, stale := b.Apply(root, findings, ranKeys)
+	return &report.BaselineStatus{Path: *flagBaseline, Stale: stale}, nil
+}
+
+// findingsFor returns the findings reported by one check.
+func findingsFor(fs []checks.Finding, key string) []checks.Finding {
+	var out []checks.Finding
+	for _, f := range fs {
+		if f.Check == key {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func statusForCheck(c checks.Check, all []checks.Finding) report.CheckStatus {
+	var fs []checks.Finding
+	suppressed := 0
+	for _, f := range all {
+		if f.Suppressed {
+			suppressed++
+			continue
+		}
+		fs = append(fs, f)
+	}
 	status := report.CheckStatus{
 		Check:       c.Key(),
 		Description: c.Description(),
 		Status:      "pass",
 		Findings:    len(fs),
+		Suppressed:  suppressed,
 	}
 	if len(fs) == 0 {
 		return status
@@ -172,6 +533,17 @@ func statusForCheck(c checks.Check, fs []checks.Finding) report.CheckStatus {
 	return status
 }
 
+// erroredStatus describes a check that failed to run or timed out.
+func erroredStatus(c checks.Check, msg string) report.CheckStatus {
+	return report.CheckStatus{
+		Check:       c.Key(),
+		Description: c.Description(),
+		Status:      "errored",
+		Level:       checks.LevelError,
+		Error:       msg,
+	}
+}
+
 func highestLevel(fs []checks.Finding) checks.Level {
 	level := checks.LevelInfo
 	for _, f := range fs {
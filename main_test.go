@@ -1,13 +1,20 @@
 package main
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hittegit/yardstick/internal/checks"
+	"github.com/hittegit/yardstick/internal/config"
+	"github.com/hittegit/yardstick/internal/repo"
 )
 
 func snapshotFlags() func() {
@@ -17,6 +24,12 @@ func snapshotFlags() func() {
 	only := *flagOnly
 	list := *flagList
 	version := *flagVersion
+	cfg := *flagConfig
+	base := *flagBaseline
+	updateBase := *flagUpdateBaseline
+	jobs := *flagJobs
+	timeout := *flagTimeout
+	rev := *flagRev
 	return func() {
 		*flagFormat = format
 		*flagPath = path
@@ -24,6 +37,12 @@ func snapshotFlags() func() {
 		*flagOnly = only
 		*flagList = list
 		*flagVersion = version
+		*flagConfig = cfg
+		*flagBaseline = base
+		*flagUpdateBaseline = updateBase
+		*flagJobs = jobs
+		*flagTimeout = timeout
+		*flagRev = rev
 	}
 }
 
@@ -57,6 +76,91 @@ func TestRun_StrictFailsOnWarn(t *testing.T) {
 	}
 }
 
+func TestRun_ScansTarball(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	p := filepath.Join(t.TempDir(), "project-1.0.tar.gz")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, body := range map[string]string{
+		"project-1.0/README.md":       "# Project\n",
+		"project-1.0/.yardstick.yml":  "strict: true\n",
+		"project-1.0/docs/install.md": "# Install\n",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []interface{ Close() error }{tw, gz, f} {
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	*flagPath = p
+	*flagFormat = "json"
+	*flagOnly = "readme_links"
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	// The archive's own config applies: strict turns README warnings into
+	// a failure.
+	*flagOnly = "readme"
+	if err := run(context.Background()); err == nil || !strings.Contains(err.Error(), "policy violations") {
+		t.Fatalf("expected strict policy failure from the archive config, got %v", err)
+	}
+}
+
+func TestRun_ScansGitRevision(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "empty")
+	if err := os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("# Changelog\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "CHANGELOG.md")
+	git("commit", "-q", "-m", "changelog")
+	// The working tree no longer matches HEAD; -rev must not look at it.
+	if err := os.Remove(filepath.Join(dir, "CHANGELOG.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	*flagPath = dir
+	*flagOnly = "changelog"
+	*flagStrict = true
+	*flagFormat = "json"
+	for rev, wantPass := range map[string]bool{"": false, "HEAD": true, "HEAD~1": false} {
+		*flagRev = rev
+		err := run(context.Background())
+		if (err == nil) != wantPass {
+			t.Fatalf("-rev %q: run returned %v, want pass=%v", rev, err, wantPass)
+		}
+	}
+	*flagRev = "missing"
+	if err := run(context.Background()); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected unknown revision error, got %v", err)
+	}
+}
+
 func TestRun_InvalidFormat(t *testing.T) {
 	t.Cleanup(snapshotFlags())
 	*flagFormat = "yaml"
@@ -66,6 +170,40 @@ func TestRun_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestRun_MachineFormats(t *testing.T) {
+	for _, format := range []string{"sarif", "junit", "markdown", "html"} {
+		t.Run(format, func(t *testing.T) {
+			t.Cleanup(snapshotFlags())
+			*flagPath = t.TempDir()
+			*flagFormat = format
+			*flagOnly = "manifest"
+			if err := run(context.Background()); err != nil {
+				t.Fatalf("run returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRun_GitHubFormatWritesStepSummary(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+	*flagPath = t.TempDir()
+	*flagFormat = "github"
+	*flagOnly = "readme"
+	*flagStrict = false
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	b, err := os.ReadFile(summary)
+	if err != nil {
+		t.Fatalf("read step summary: %v", err)
+	}
+	if !strings.Contains(string(b), "| `readme` | fail | warn | 1 |") {
+		t.Fatalf("step summary missing readme status:\n%s", b)
+	}
+}
+
 func TestRun_OnlyRejectsUnknownCheck(t *testing.T) {
 	t.Cleanup(snapshotFlags())
 	*flagFormat = "table"
@@ -79,6 +217,271 @@ func TestRun_OnlyRejectsUnknownCheck(t *testing.T) {
 	}
 }
 
+func TestRun_ConfigOverridesLevelAndDisablesChecks(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	dir := t.TempDir()
+	cfg := "strict: true\nchecks:\n  readme:\n    level: info\n  license:\n    enabled: false\n"
+	if err := os.WriteFile(filepath.Join(dir, ".yardstick.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	*flagPath = dir
+	*flagFormat = "json"
+
+	// readme warnings are demoted to info, so strict mode from the config passes.
+	*flagOnly = "readme"
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("expected demoted readme findings to pass strict mode, got %v", err)
+	}
+
+	// Disabled checks are skipped, but an explicit -only still runs them.
+	*flagOnly = "license"
+	if err := run(context.Background()); err == nil {
+		t.Fatalf("expected -only license to run despite config and fail strict mode")
+	}
+}
+
+func TestRun_ConfigUnknownCheckFailsWithLine(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	dir := t.TempDir()
+	cfg := "checks:\n  readme: {}\n  does-not-exist:\n    enabled: false\n"
+	if err := os.WriteFile(filepath.Join(dir, ".yardstick.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	*flagPath = dir
+	*flagFormat = "json"
+	err := run(context.Background())
+	if err == nil {
+		t.Fatalf("expected config validation error, got nil")
+	}
+	if !strings.Contains(err.Error(), ".yardstick.yml:3") || !strings.Contains(err.Error(), "does-not-exist") {
+		t.Fatalf("error missing location or key, got: %v", err)
+	}
+}
+
+func TestRun_ExplicitConfigPath(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	dir := t.TempDir()
+	cfgPath := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(cfgPath, []byte(`{"checks": {"readme": {"level": "error"}}}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	*flagPath = dir
+	*flagConfig = cfgPath
+	*flagOnly = "readme"
+	*flagFormat = "json"
+	*flagStrict = false
+	if err := run(context.Background()); err == nil {
+		t.Fatalf("expected readme findings promoted to error to fail, got nil")
+	}
+}
+
+func TestRun_ConfigSettingsReachChecks(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	dir := t.TempDir()
+	cfg := "checks:\n  readme:\n    settings:\n      sections: [\"## Runbook\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, ".yardstick.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Svc\n\n## Runbook\n"), 0o644); err != nil {
+		t.Fatalf("write README: %v", err)
+	}
+	*flagPath = dir
+	*flagOnly = "readme"
+	*flagFormat = "json"
+	*flagStrict = true
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("expected configured sections to satisfy readme, got %v", err)
+	}
+}
+
+func TestRun_BaselineSuppressesKnownFindings(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	dir := t.TempDir()
+	basePath := filepath.Join(t.TempDir(), "baseline.json")
+	*flagPath = dir
+	*flagOnly = "license,changelog"
+	*flagFormat = "json"
+	*flagStrict = true
+	*flagBaseline = basePath
+
+	// Recording the baseline suppresses everything currently found.
+	*flagUpdateBaseline = true
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("recording a baseline should pass, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "baseline.json")); !os.IsNotExist(err) {
+		t.Fatalf("baseline must only be written to the -baseline path")
+	}
+
+	*flagUpdateBaseline = false
+	if err := run(context.Background()); err != nil {
+		t.Fatalf("known findings should be suppressed, got %v", err)
+	}
+
+	// A new finding still fails strict mode.
+	*flagOnly = "license,changelog,readme"
+	if err := run(context.Background()); err == nil {
+		t.Fatalf("expected new readme finding to fail strict mode")
+	}
+}
+
+func TestRun_UpdateBaselineRequiresPath(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	*flagFormat = "json"
+	*flagUpdateBaseline = true
+	*flagBaseline = ""
+	if err := run(context.Background()); err == nil {
+		t.Fatalf("expected usage error without -baseline")
+	}
+}
+
+func TestRun_InvalidJobsAndTimeout(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	*flagJobs = 0
+	if err := run(context.Background()); err == nil || !strings.Contains(err.Error(), "-jobs") {
+		t.Fatalf("expected -jobs usage error, got %v", err)
+	}
+	*flagJobs = 1
+	*flagTimeout = -time.Second
+	if err := run(context.Background()); err == nil || !strings.Contains(err.Error(), "-timeout") {
+		t.Fatalf("expected -timeout usage error, got %v", err)
+	}
+}
+
+// funcCheck adapts a function to checks.Check for runner tests.
+type funcCheck struct {
+	key string
+	run func(ctx context.Context) ([]checks.Finding, error)
+}
+
+func (c funcCheck) Key() string         { return c.key }
+func (c funcCheck) Description() string { return c.key }
+func (c funcCheck) Run(ctx context.Context, root string, opts checks.Options) ([]checks.Finding, error) {
+	return c.run(ctx)
+}
+
+func sleepCheck(key string, d time.Duration) funcCheck {
+	return funcCheck{key: key, run: func(ctx context.Context) ([]checks.Finding, error) {
+		time.Sleep(d)
+		return []checks.Finding{{Check: key, Level: checks.LevelInfo, Message: key}}, nil
+	}}
+}
+
+func emptyIndex(t *testing.T) *repo.Index {
+	t.Helper()
+	idx, err := repo.Build(context.Background(), t.TempDir())
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	return idx
+}
+
+func TestRunChecks_OrderIsDeterministic(t *testing.T) {
+	cs := []checks.Check{
+		sleepCheck("a", 30*time.Millisecond),
+		sleepCheck("b", 0),
+		sleepCheck("c", 15*time.Millisecond),
+		sleepCheck("d", 0),
+	}
+	results := runChecks(context.Background(), emptyIndex(t), cs, nil, 4, 0)
+	for i, r := range results {
+		if r.err != nil || len(r.findings) != 1 || r.findings[0].Check != cs[i].Key() {
+			t.Fatalf("result %d out of order: %+v", i, r)
+		}
+	}
+}
+
+func TestRunChecks_TimeoutsArePerCheck(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	cs := []checks.Check{
+		funcCheck{key: "watches_ctx", run: func(ctx context.Context) ([]checks.Finding, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}},
+		funcCheck{key: "ignores_ctx", run: func(ctx context.Context) ([]checks.Finding, error) {
+			<-release
+			return nil, nil
+		}},
+		sleepCheck("fast", 0),
+		sleepCheck("slow_but_allowed", 50*time.Millisecond),
+	}
+	cfg := &config.Config{Checks: map[string]config.CheckConfig{
+		"slow_but_allowed": {Timeout: 5 * time.Second},
+	}}
+
+	results := runChecks(context.Background(), emptyIndex(t), cs, cfg, 2, 20*time.Millisecond)
+	for i, want := range []bool{true, true, false, false} {
+		if results[i].timedOut != want {
+			t.Fatalf("%s: timedOut = %v, want %v", cs[i].Key(), results[i].timedOut, want)
+		}
+	}
+	if results[0].timeout != 20*time.Millisecond {
+		t.Fatalf("timeout not recorded: %+v", results[0])
+	}
+	if len(results[3].findings) != 1 {
+		t.Fatalf("config timeout should override -timeout: %+v", results[3])
+	}
+}
+
+func TestRun_ErroredCheckDoesNotHideOthers(t *testing.T) {
+	t.Cleanup(snapshotFlags())
+	prev := registry
+	t.Cleanup(func() { registry = prev })
+	var ranOK bool
+	registry = func() []checks.Check {
+		return []checks.Check{
+			funcCheck{key: "broken", run: func(ctx context.Context) ([]checks.Finding, error) {
+				return nil, errors.New("permission denied")
+			}},
+			funcCheck{key: "healthy", run: func(ctx context.Context) ([]checks.Finding, error) {
+				ranOK = true
+				return nil, nil
+			}},
+		}
+	}
+	*flagPath = t.TempDir()
+	*flagFormat = "json"
+
+	err := run(context.Background())
+	if !errors.Is(err, errChecksErrored) || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("expected errored checks error naming broken, got %v", err)
+	}
+	if exitCode(err) != 3 {
+		t.Fatalf("errored checks should exit 3, got %d", exitCode(err))
+	}
+	if !ranOK {
+		t.Fatalf("healthy check should still run")
+	}
+	if exitCode(errors.New("policy violations found")) != 2 {
+		t.Fatalf("policy violations should keep exit code 2")
+	}
+}
+
+func TestErroredStatus(t *testing.T) {
+	s := erroredStatus(sleepCheck("slow", 0), "timed out after 1s")
+	if s.Status != "errored" || s.Level != checks.LevelError || s.Error != "timed out after 1s" || s.Findings != 0 {
+		t.Fatalf("unexpected errored status: %+v", s)
+	}
+}
+
+func TestStatusForCheck_SuppressedFindingsPass(t *testing.T) {
+	st := statusForCheck(fakeCheck{key: "license", desc: "l"}, []checks.Finding{
+		{Check: "license", Level: checks.LevelWarn, Suppressed: true},
+	})
+	if st.Status != "pass" || st.Findings != 0 || st.Suppressed != 1 {
+		t.Fatalf("suppressed findings should not fail the check: %+v", st)
+	}
+}
+
+func TestOverrideLevel_KeepsInfo(t *testing.T) {
+	fs := []checks.Finding{{Level: checks.LevelInfo}, {Level: checks.LevelWarn}}
+	overrideLevel(fs, checks.LevelError)
+	if fs[0].Level != checks.LevelInfo || fs[1].Level != checks.LevelError {
+		t.Fatalf("unexpected levels after override: %+v", fs)
+	}
+}
+
 func TestSplitCSV_Trimmed(t *testing.T) {
 	got := splitCSV("manifest, readme ,  license,,")
 	want := []string{"manifest", "readme", "license"}